@@ -0,0 +1,161 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Connected Account Types
+const (
+	AccountStandard = "standard"
+	AccountExpress  = "express"
+	AccountCustom   = "custom"
+)
+
+// Account Rejection Reasons
+const (
+	RejectFraud          = "fraud"
+	RejectTermsOfService = "terms_of_service"
+	RejectOther          = "other"
+)
+
+// Payout Schedule Intervals
+const (
+	PayoutManual  = "manual"
+	PayoutDaily   = "daily"
+	PayoutWeekly  = "weekly"
+	PayoutMonthly = "monthly"
+)
+
+// Account represents a connected account on your platform.
+//
+// see https://stripe.com/docs/api#account_object
+type Account struct {
+	ID               string            `json:"id"`
+	Type             string            `json:"type"`
+	Email            string            `json:"email,omitempty"`
+	Country          string            `json:"country"`
+	DefaultCurrency  string            `json:"default_currency"`
+	BusinessType     string            `json:"business_type,omitempty"`
+	Company          *Company          `json:"company,omitempty"`
+	ChargesEnabled   bool              `json:"charges_enabled"`
+	PayoutsEnabled   bool              `json:"payouts_enabled"`
+	DetailsSubmitted bool              `json:"details_submitted"`
+	Settings         *AccountSettings  `json:"settings,omitempty"`
+	Created          UnixTime          `json:"created"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// Company holds the legal entity details of a connected account whose
+// business type is company.
+type Company struct {
+	Name    string   `json:"name,omitempty"`
+	Phone   string   `json:"phone,omitempty"`
+	Address *Address `json:"address,omitempty"`
+}
+
+// AccountSettings holds the options that customize how a connected account
+// operates.
+type AccountSettings struct {
+	Payouts *PayoutSettings `json:"payouts,omitempty"`
+}
+
+// PayoutSettings holds the options for a connected account's payouts.
+type PayoutSettings struct {
+	Schedule              *PayoutSchedule `json:"schedule,omitempty"`
+	StatementDescriptor   string          `json:"statement_descriptor,omitempty"`
+	DebitNegativeBalances bool            `json:"debit_negative_balances"`
+}
+
+// PayoutSchedule describes when a connected account's balance is paid out
+// automatically.
+type PayoutSchedule struct {
+	// How often payouts are sent, such as PayoutDaily. Manual payouts are
+	// only sent when created with the PayoutClient.
+	Interval string `json:"interval"`
+
+	// The number of days a charge's funds are held before they are paid
+	// out. Zero leaves the delay unchanged when updating.
+	DelayDays int `json:"delay_days"`
+
+	// The day of the week ("monday" to "sunday") weekly payouts are sent.
+	WeeklyAnchor string `json:"weekly_anchor,omitempty"`
+
+	// The day of the month (1 to 31) monthly payouts are sent. Payouts are
+	// sent on the last day of shorter months.
+	MonthlyAnchor int `json:"monthly_anchor,omitempty"`
+}
+
+// LoginLink is a single-use link that signs the owner of an Express account
+// into their Stripe dashboard.
+//
+// see https://stripe.com/docs/api#login_link_object
+type LoginLink struct {
+	URL     string   `json:"url"`
+	Created UnixTime `json:"created"`
+}
+
+// AccountClient encapsulates operations for querying connected accounts using
+// the Stripe REST API.
+type AccountClient struct{}
+
+// Retrieves the connected account with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_account
+func (AccountClient) Get(id string) (*Account, error) {
+	res := &Account{}
+	return res, query("GET", "/accounts/"+url.QueryEscape(id), nil, res)
+}
+
+// UpdatePayoutSchedule sets the payout schedule of the connected account
+// with the given ID, such as to switch it between daily and manual payouts.
+//
+// see https://stripe.com/docs/api#update_account
+func (AccountClient) UpdatePayoutSchedule(id string, schedule *PayoutSchedule) (*Account, error) {
+	values := url.Values{"settings[payouts][schedule][interval]": {schedule.Interval}}
+	if schedule.DelayDays > 0 {
+		values.Add("settings[payouts][schedule][delay_days]", strconv.Itoa(schedule.DelayDays))
+	}
+	if schedule.WeeklyAnchor != "" {
+		values.Add("settings[payouts][schedule][weekly_anchor]", schedule.WeeklyAnchor)
+	}
+	if schedule.MonthlyAnchor > 0 {
+		values.Add("settings[payouts][schedule][monthly_anchor]", strconv.Itoa(schedule.MonthlyAnchor))
+	}
+	res := &Account{}
+	return res, query("POST", "/accounts/"+url.QueryEscape(id), values, res)
+}
+
+// Rejects the connected account with the given ID, flagging it as fraudulent
+// or otherwise in breach of your terms. Rejected accounts can no longer accept
+// charges or receive payouts.
+//
+// see https://stripe.com/docs/api#reject_account
+func (AccountClient) Reject(id, reason string) (*Account, error) {
+	values := url.Values{"reason": {reason}}
+	res := &Account{}
+	return res, query("POST", "/accounts/"+url.QueryEscape(id)+"/reject", values, res)
+}
+
+// Deletes the connected account with the given ID. Only test-mode accounts,
+// and live-mode Custom or Express accounts with a zero balance, can be
+// deleted.
+//
+// see https://stripe.com/docs/api#delete_account
+func (AccountClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/accounts/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Creates a single-use login link for the Express account with the given ID.
+// The link expires shortly after creation, so it should be created on demand
+// and the user redirected to it immediately.
+//
+// see https://stripe.com/docs/api#create_login_link
+func (AccountClient) CreateLoginLink(id string) (*LoginLink, error) {
+	res := &LoginLink{}
+	return res, query("POST", "/accounts/"+url.QueryEscape(id)+"/login_links", nil, res)
+}
@@ -0,0 +1,83 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateLoginLink will test that a login link is requested for the given
+// account, and that the dashboard URL is decoded from the response.
+func TestCreateLoginLink(t *testing.T) {
+	req, done := mockServer(`{"object": "login_link", "created": 1500000000, "url": "https://connect.stripe.com/express/abc"}`)
+	defer done()
+
+	link, err := Accounts.CreateLoginLink("acct_1")
+	if err != nil {
+		t.Errorf("Expected Login Link, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/accounts/acct_1/login_links" {
+		t.Errorf("Expected POST /v1/accounts/acct_1/login_links, got %s %s", req.Method, req.Path)
+	}
+	if link.URL != "https://connect.stripe.com/express/abc" {
+		t.Errorf("Expected Login Link URL, got %s", link.URL)
+	}
+	if link.Created.Unix() != 1500000000 {
+		t.Errorf("Expected Login Link Created 1500000000, got %d", link.Created.Unix())
+	}
+}
+
+// TestRejectAccount will test that the rejection reason is sent to Stripe.
+func TestRejectAccount(t *testing.T) {
+	req, done := mockServer(`{"id": "acct_1", "charges_enabled": false}`)
+	defer done()
+
+	acct, err := Accounts.Reject("acct_1", RejectFraud)
+	if err != nil {
+		t.Errorf("Expected Account, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/accounts/acct_1/reject" {
+		t.Errorf("Expected POST /v1/accounts/acct_1/reject, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("reason"); got != RejectFraud {
+		t.Errorf("Expected reason %s, got %s", RejectFraud, got)
+	}
+	if acct.ID != "acct_1" || acct.ChargesEnabled {
+		t.Errorf("Expected rejected Account acct_1, got %+v", acct)
+	}
+}
+
+// TestUpdatePayoutSchedule will test that an account's payout schedule is
+// updated, and decoded from its settings.
+func TestUpdatePayoutSchedule(t *testing.T) {
+	req, done := mockServer(`{"id": "acct_1", "settings": {"payouts": {"schedule": {"interval": "weekly", "weekly_anchor": "friday", "delay_days": 7}}}}`)
+	defer done()
+
+	acct, err := Accounts.UpdatePayoutSchedule("acct_1", &PayoutSchedule{
+		Interval:     PayoutWeekly,
+		WeeklyAnchor: "friday",
+		DelayDays:    7,
+	})
+	if err != nil {
+		t.Errorf("Expected Account, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/accounts/acct_1" {
+		t.Errorf("Expected POST /v1/accounts/acct_1, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"settings[payouts][schedule][interval]":      "weekly",
+		"settings[payouts][schedule][weekly_anchor]": "friday",
+		"settings[payouts][schedule][delay_days]":    "7",
+	})
+	if _, ok := req.Form["settings[payouts][schedule][monthly_anchor]"]; ok {
+		t.Errorf("Expected no monthly anchor for a weekly schedule")
+	}
+	if acct.Settings == nil || acct.Settings.Payouts == nil || acct.Settings.Payouts.Schedule == nil {
+		t.Errorf("Expected Account payout schedule, got %+v", acct.Settings)
+		return
+	}
+	if s := acct.Settings.Payouts.Schedule; s.Interval != PayoutWeekly || s.DelayDays != 7 {
+		t.Errorf("Expected weekly schedule with 7 days delay, got %+v", s)
+	}
+}
@@ -0,0 +1,65 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Address represents a postal address, shared by card billing addresses,
+// shipping details and the addresses of connected account owners.
+type Address struct {
+	Line1      string `json:"line1,omitempty"`
+	Line2      string `json:"line2,omitempty"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	Country    string `json:"country,omitempty"`
+}
+
+// appendAddress adds the non-empty address fields to values, nested under the
+// given prefix (e.g. address[line1]).
+func appendAddress(values url.Values, prefix string, a *Address) {
+	fields := []struct{ key, value string }{
+		{"line1", a.Line1},
+		{"line2", a.Line2},
+		{"city", a.City},
+		{"state", a.State},
+		{"postal_code", a.PostalCode},
+		{"country", a.Country},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			values.Add(prefix+"["+f.key+"]", f.value)
+		}
+	}
+}
+
+// ShippingDetails holds where and how an order is shipped. Shipping details
+// help Radar screen payments for physical goods.
+type ShippingDetails struct {
+	Name    string   `json:"name"`
+	Phone   string   `json:"phone,omitempty"`
+	Address *Address `json:"address"`
+
+	// The delivery service and tracking number, once the order has shipped.
+	Carrier        string `json:"carrier,omitempty"`
+	TrackingNumber string `json:"tracking_number,omitempty"`
+}
+
+// appendShippingDetails adds the non-empty shipping details to values,
+// nested under the given prefix (e.g. shipping[name]).
+func appendShippingDetails(values url.Values, prefix string, s *ShippingDetails) {
+	fields := []struct{ key, value string }{
+		{"name", s.Name},
+		{"phone", s.Phone},
+		{"carrier", s.Carrier},
+		{"tracking_number", s.TrackingNumber},
+	}
+	for _, f := range fields {
+		if f.value != "" {
+			values.Add(prefix+"["+f.key+"]", f.value)
+		}
+	}
+	if s.Address != nil {
+		appendAddress(values, prefix+"[address]", s.Address)
+	}
+}
@@ -0,0 +1,63 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// ApplePayDomain represents a web domain registered for Apple Pay. Apple Pay
+// is only offered on registered domains, after the domain association file
+// is served from the domain.
+//
+// see https://stripe.com/docs/apple-pay/web#going-live
+type ApplePayDomain struct {
+	ID         string   `json:"id"`
+	DomainName string   `json:"domain_name"`
+	Created    UnixTime `json:"created"`
+	Livemode   bool     `json:"livemode"`
+}
+
+// ApplePayDomainClient encapsulates operations for registering, removing
+// and querying Apple Pay domains using the Stripe REST API.
+type ApplePayDomainClient struct{}
+
+// Registers the given domain, such as "example.com", for Apple Pay.
+//
+// see https://stripe.com/docs/api#create_apple_pay_domain
+func (ApplePayDomainClient) Create(domainName string) (*ApplePayDomain, error) {
+	values := url.Values{"domain_name": {domainName}}
+	res := &ApplePayDomain{}
+	return res, query("POST", "/apple_pay/domains", values, res)
+}
+
+// Retrieves the Apple Pay Domain with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_apple_pay_domain
+func (ApplePayDomainClient) Get(id string) (*ApplePayDomain, error) {
+	res := &ApplePayDomain{}
+	return res, query("GET", "/apple_pay/domains/"+url.QueryEscape(id), nil, res)
+}
+
+// Removes the Apple Pay Domain with the given ID.
+//
+// see https://stripe.com/docs/api#delete_apple_pay_domain
+func (ApplePayDomainClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/apple_pay/domains/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Apple Pay Domains, optionally only the one with the
+// given domain name.
+//
+// see https://stripe.com/docs/api#list_apple_pay_domains
+func (ApplePayDomainClient) List(domainName string, limit int, before, after string) ([]*ApplePayDomain, bool, error) {
+	var data []*ApplePayDomain
+	params := listParams(limit, before, after)
+	if domainName != "" {
+		params.Add("domain_name", domainName)
+	}
+	list, err := queryList("/apple_pay/domains", params, &data)
+	return data, list.More, err
+}
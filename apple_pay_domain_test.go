@@ -0,0 +1,27 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateApplePayDomain will test that a domain is registered for Apple
+// Pay.
+func TestCreateApplePayDomain(t *testing.T) {
+	req, done := mockServer(`{"id": "apwc_1", "domain_name": "example.com"}`)
+	defer done()
+
+	domain, err := ApplePayDomains.Create("example.com")
+	if err != nil {
+		t.Errorf("Expected Apple Pay Domain, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/apple_pay/domains" {
+		t.Errorf("Expected POST /v1/apple_pay/domains, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("domain_name"); got != "example.com" {
+		t.Errorf("Expected param domain_name=example.com, got %q", got)
+	}
+	if domain.DomainName != "example.com" {
+		t.Errorf("Expected Apple Pay Domain example.com, got %q", domain.DomainName)
+	}
+}
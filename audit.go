@@ -0,0 +1,57 @@
+package stripe
+
+import (
+	"sort"
+	"time"
+)
+
+// DuplicateCharges is a group of successful charges tagged with the same
+// order, which usually means the order was billed more than once.
+type DuplicateCharges struct {
+	// The order, as tagged in the charges' metadata.
+	Order string
+
+	// The paid, unrefunded charges for the order, oldest first.
+	Charges []*Charge
+}
+
+// FindDuplicateCharges groups the charges by the value of the given metadata
+// key (such as an order ID), and returns the groups with more than one paid
+// charge that has not been fully refunded, sorted by order. Charges without
+// the metadata key are ignored.
+func FindDuplicateCharges(charges []*Charge, metadataKey string) []*DuplicateCharges {
+	orders := make(map[string][]*Charge)
+	for _, c := range charges {
+		order := c.Metadata[metadataKey]
+		if order == "" || !c.Paid || c.Refunded {
+			continue
+		}
+		orders[order] = append(orders[order], c)
+	}
+
+	var dups []*DuplicateCharges
+	for order, charges := range orders {
+		if len(charges) < 2 {
+			continue
+		}
+		sort.Slice(charges, func(i, j int) bool {
+			return charges[i].Created.Before(charges[j].Created.Time)
+		})
+		dups = append(dups, &DuplicateCharges{order, charges})
+	}
+	sort.Slice(dups, func(i, j int) bool {
+		return dups[i].Order < dups[j].Order
+	})
+	return dups
+}
+
+// AuditCharges lists every charge created since the given time and reports
+// orders with duplicate successful charges, as FindDuplicateCharges does.
+// It is meant to run periodically as an early warning of double billing.
+func AuditCharges(since time.Time, metadataKey string) ([]*DuplicateCharges, error) {
+	charges, err := Charges.listCreated(&DateFilter{Gte: &UnixTime{since}}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return FindDuplicateCharges(charges, metadataKey), nil
+}
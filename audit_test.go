@@ -0,0 +1,57 @@
+package stripe
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+// TestAuditCharges will test that orders with more than one paid, unrefunded
+// charge are reported, across every page of charges.
+func TestAuditCharges(t *testing.T) {
+	pages := []string{
+		`{"object": "list", "has_more": true, "data": [
+			{"id": "ch_1", "paid": true, "created": 1500000300, "metadata": {"order_id": "A"}},
+			{"id": "ch_2", "paid": true, "created": 1500000200, "metadata": {"order_id": "B"}},
+			{"id": "ch_3", "paid": true, "created": 1500000100, "metadata": {"order_id": "A"}}
+		]}`,
+		`{"object": "list", "has_more": false, "data": [
+			{"id": "ch_4", "paid": false, "created": 1500000050, "metadata": {"order_id": "B"}},
+			{"id": "ch_5", "paid": true, "refunded": true, "created": 1500000040, "metadata": {"order_id": "B"}},
+			{"id": "ch_6", "paid": true, "created": 1500000030}
+		]}`,
+	}
+	var reqs []*mockRequest
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		reqs = append(reqs, r)
+		if r.Form.Get("starting_after") == "" {
+			w.Write([]byte(pages[0]))
+		} else {
+			w.Write([]byte(pages[1]))
+		}
+	})
+	defer done()
+
+	dups, err := AuditCharges(time.Unix(1500000000, 0), "order_id")
+	if err != nil {
+		t.Errorf("Expected duplicate report, got Error %s", err.Error())
+		return
+	}
+	if len(reqs) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(reqs))
+		return
+	}
+	if got := reqs[0].Form.Get("created[gte]"); got != "1500000000" {
+		t.Errorf("Expected param created[gte]=1500000000, got %q", got)
+	}
+	if got := reqs[1].Form.Get("starting_after"); got != "ch_3" {
+		t.Errorf("Expected param starting_after=ch_3, got %q", got)
+	}
+	if len(dups) != 1 || dups[0].Order != "A" || len(dups[0].Charges) != 2 {
+		t.Errorf("Expected order A to be duplicated, got %v", dups)
+		return
+	}
+	if dups[0].Charges[0].ID != "ch_3" || dups[0].Charges[1].ID != "ch_1" {
+		t.Errorf("Expected duplicate charges oldest first, got %s, %s", dups[0].Charges[0].ID, dups[0].Charges[1].ID)
+	}
+}
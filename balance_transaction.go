@@ -0,0 +1,91 @@
+package stripe
+
+import (
+	"net/url"
+	"strings"
+)
+
+// Balance Transaction Statuses
+const (
+	BalanceTransactionAvailable = "available"
+	BalanceTransactionPending   = "pending"
+)
+
+// BalanceTransaction represents a change to your Stripe balance, such as a
+// charge, refund, fee or payout.
+//
+// see https://stripe.com/docs/api#balance_transaction_object
+type BalanceTransaction struct {
+	ID          string       `json:"id"`
+	Amount      int          `json:"amount"`
+	Currency    string       `json:"currency"`
+	Net         int          `json:"net"`
+	Fee         int          `json:"fee"`
+	FeeDetails  []*FeeDetail `json:"fee_details"`
+	Type        string       `json:"type"`
+	Status      string       `json:"status"`
+	Description string       `json:"description,omitempty"`
+	Source      string       `json:"source"`
+	Created     UnixTime     `json:"created"`
+	AvailableOn UnixTime     `json:"available_on"`
+}
+
+// FeeDetail describes one of the fees that make up a BalanceTransaction's
+// fee.
+type FeeDetail struct {
+	Amount      int    `json:"amount"`
+	Currency    string `json:"currency"`
+	Description string `json:"description,omitempty"`
+	Type        string `json:"type"`
+}
+
+// BalanceTransactionClient encapsulates operations for querying your balance
+// history using the Stripe REST API.
+type BalanceTransactionClient struct{}
+
+// Retrieves the balance transaction with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_balance_transaction
+func (BalanceTransactionClient) Get(id string) (*BalanceTransaction, error) {
+	res := &BalanceTransaction{}
+	return res, query("GET", "/balance/history/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of balance transactions at the specified range.
+//
+// see https://stripe.com/docs/api#balance_history
+func (BalanceTransactionClient) List(limit int, before, after string) ([]*BalanceTransaction, bool, error) {
+	var data []*BalanceTransaction
+	list, err := queryList("/balance/history", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+// ListForPayout returns every balance transaction that was paid out in the
+// payout (po_) or transfer (tr_) with the given ID, requesting as many pages as
+// needed of the given size, or of the size set with SetPageSize if 0. This is
+// the set of transactions to reconcile against a bank deposit.
+//
+// see https://stripe.com/docs/api#balance_history
+func (BalanceTransactionClient) ListForPayout(id string, size int) ([]*BalanceTransaction, error) {
+	filter := "payout"
+	if strings.HasPrefix(id, "tr_") {
+		filter = "transfer"
+	}
+
+	var all []*BalanceTransaction
+	after := ""
+	for {
+		var data []*BalanceTransaction
+		params := listParams(pageSize(size), "", after)
+		params.Add(filter, id)
+		list, err := queryList("/balance/history", params, &data)
+		if err != nil {
+			return all, err
+		}
+		all = append(all, data...)
+		if !list.More || len(data) == 0 {
+			return all, nil
+		}
+		after = data[len(data)-1].ID
+	}
+}
@@ -0,0 +1,42 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+// TestListForPayout will test that every page of a payout's balance
+// transactions is requested, filtered by the payout.
+func TestListForPayout(t *testing.T) {
+	var afters []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("payout") != "po_1" {
+			t.Errorf("Expected payout po_1, got %q", r.URL.RawQuery)
+		}
+		after := r.URL.Query().Get("starting_after")
+		afters = append(afters, after)
+		if after == "" {
+			fmt.Fprint(w, `{"object": "list", "has_more": true, "data": [{"id": "txn_1"}, {"id": "txn_2"}]}`)
+		} else {
+			fmt.Fprint(w, `{"object": "list", "has_more": false, "data": [{"id": "txn_3"}]}`)
+		}
+	}))
+	defer srv.Close()
+	prev := _url
+	SetUrl(srv.URL)
+	defer SetUrl(prev)
+
+	txns, err := BalanceTransactions.ListForPayout("po_1", 0)
+	if err != nil {
+		t.Errorf("Expected Balance Transactions, got Error %s", err.Error())
+		return
+	}
+	if len(txns) != 3 || txns[2].ID != "txn_3" {
+		t.Errorf("Expected 3 Balance Transactions, got %v", txns)
+	}
+	if len(afters) != 2 || afters[1] != "txn_2" {
+		t.Errorf("Expected second page after txn_2, got %v", afters)
+	}
+}
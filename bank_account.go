@@ -0,0 +1,152 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Bank Account Statuses
+const (
+	BankAccountNew                = "new"
+	BankAccountValidated          = "validated"
+	BankAccountVerified           = "verified"
+	BankAccountVerificationFailed = "verification_failed"
+	BankAccountErrored            = "errored"
+)
+
+// Bank Account Holder Types
+const (
+	AccountHolderIndividual = "individual"
+	AccountHolderCompany    = "company"
+)
+
+// BankAccount represents a bank account attached to a customer, which can be
+// debited by ACH once it has been verified.
+//
+// see https://stripe.com/docs/api#customer_bank_account_object
+type BankAccount struct {
+	ID                string            `json:"id"`
+	AccountHolderName string            `json:"account_holder_name,omitempty"`
+	AccountHolderType string            `json:"account_holder_type,omitempty"`
+	BankName          string            `json:"bank_name,omitempty"`
+	Country           string            `json:"country"`
+	Currency          string            `json:"currency"`
+	Last4             string            `json:"last4"`
+	RoutingNumber     string            `json:"routing_number,omitempty"`
+	Fingerprint       string            `json:"fingerprint"`
+	Status            string            `json:"status"`
+	Customer          string            `json:"customer,omitempty"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
+
+// BankAccountParams encapsulates options for attaching a Bank Account to a
+// customer.
+type BankAccountParams struct {
+	// (Optional) A bank account token. If set, the account details below are
+	// ignored.
+	Token string
+
+	// The country the bank account is in.
+	Country string
+
+	// The currency paid out to the bank account.
+	Currency string
+
+	// The bank account number, as a string without any separators.
+	AccountNumber string
+
+	// The routing number, sort code or other country-appropriate institution
+	// number for the bank account.
+	RoutingNumber string
+
+	// (Optional) The name of the person or business that owns the account.
+	AccountHolderName string
+
+	// (Optional) The type of entity that holds the account. Either individual
+	// or company.
+	AccountHolderType string
+
+	Metadata map[string]string
+}
+
+// BankAccountClient encapsulates operations for creating, deleting, verifying
+// and querying customer bank accounts using the Stripe REST API.
+type BankAccountClient struct{}
+
+func (c BankAccountClient) path(customerID, bankAccountID string) string {
+	p := fmt.Sprintf("/customers/%s/sources", url.QueryEscape(customerID))
+	if bankAccountID != "" {
+		p += "/" + url.QueryEscape(bankAccountID)
+	}
+	return p
+}
+
+// Attaches a new Bank Account to the customer. The account must be verified
+// before it can be charged.
+//
+// see https://stripe.com/docs/api#customer_create_bank_account
+func (c BankAccountClient) Create(customerID string, params *BankAccountParams) (*BankAccount, error) {
+	values := make(url.Values)
+	if params.Token != "" {
+		values.Add("source", params.Token)
+	} else {
+		values.Add("source[object]", "bank_account")
+		values.Add("source[country]", params.Country)
+		values.Add("source[currency]", params.Currency)
+		values.Add("source[account_number]", params.AccountNumber)
+		if params.RoutingNumber != "" {
+			values.Add("source[routing_number]", params.RoutingNumber)
+		}
+		if params.AccountHolderName != "" {
+			values.Add("source[account_holder_name]", params.AccountHolderName)
+		}
+		if params.AccountHolderType != "" {
+			values.Add("source[account_holder_type]", params.AccountHolderType)
+		}
+	}
+	appendMetadata(values, params.Metadata)
+
+	res := &BankAccount{}
+	return res, query("POST", c.path(customerID, ""), values, res)
+}
+
+// Retrieves the Bank Account with the given ID.
+//
+// see https://stripe.com/docs/api#customer_retrieve_bank_account
+func (c BankAccountClient) Get(customerID, bankAccountID string) (*BankAccount, error) {
+	res := &BankAccount{}
+	return res, query("GET", c.path(customerID, bankAccountID), nil, res)
+}
+
+// Verifies the Bank Account with the given ID using the amounts, in cents, of
+// the two micro-deposits Stripe sent to it.
+//
+// see https://stripe.com/docs/api#customer_verify_bank_account
+func (c BankAccountClient) Verify(customerID, bankAccountID string, amounts [2]int) (*BankAccount, error) {
+	values := url.Values{
+		"amounts[]": {strconv.Itoa(amounts[0]), strconv.Itoa(amounts[1])},
+	}
+	res := &BankAccount{}
+	return res, query("POST", c.path(customerID, bankAccountID)+"/verify", values, res)
+}
+
+// Deletes the Bank Account with the given ID from the customer.
+//
+// see https://stripe.com/docs/api#customer_delete_bank_account
+func (c BankAccountClient) Delete(customerID, bankAccountID string) (bool, error) {
+	res := &DeleteResp{}
+	err := query("DELETE", c.path(customerID, bankAccountID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the customer's Bank Accounts.
+//
+// see https://stripe.com/docs/api#customer_list_bank_accounts
+func (c BankAccountClient) List(customerID string, limit int, before, after string) ([]*BankAccount, bool, error) {
+	var data []*BankAccount
+	params := listParams(limit, before, after)
+	params.Add("object", "bank_account")
+	list, err := queryList(c.path(customerID, ""), params, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,46 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestVerifyBankAccount will test that both micro-deposit amounts are sent
+// when verifying a Bank Account, and that the verified status is decoded.
+func TestVerifyBankAccount(t *testing.T) {
+	req, done := mockServer(`{"id": "ba_1", "last4": "6789", "status": "verified"}`)
+	defer done()
+
+	ba, err := BankAccounts.Verify("cus_1", "ba_1", [2]int{32, 45})
+	if err != nil {
+		t.Errorf("Expected Bank Account, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/customers/cus_1/sources/ba_1/verify" {
+		t.Errorf("Expected POST /v1/customers/cus_1/sources/ba_1/verify, got %s %s", req.Method, req.Path)
+	}
+	if amounts := req.Form["amounts[]"]; len(amounts) != 2 || amounts[0] != "32" || amounts[1] != "45" {
+		t.Errorf("Expected amounts [32 45], got %v", amounts)
+	}
+	if ba.Status != BankAccountVerified {
+		t.Errorf("Expected Bank Account Status %s, got %s", BankAccountVerified, ba.Status)
+	}
+}
+
+// TestListBankAccounts will test that only bank accounts are requested from
+// the customer's sources.
+func TestListBankAccounts(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "ba_1"}, {"id": "ba_2"}]}`)
+	defer done()
+
+	accounts, _, err := BankAccounts.List("cus_1", 10, "", "")
+	if err != nil {
+		t.Errorf("Expected Bank Account List, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("object"); got != "bank_account" {
+		t.Errorf("Expected object bank_account, got %q", got)
+	}
+	if len(accounts) != 2 {
+		t.Errorf("Expected 2 Bank Accounts, got %d", len(accounts))
+	}
+}
@@ -0,0 +1,258 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Billing Portal Subscription Cancellation Modes
+const (
+	PortalCancelImmediately = "immediately"
+	PortalCancelAtPeriodEnd = "at_period_end"
+)
+
+// BillingPortalSession represents a session of the customer portal hosted by
+// Stripe, in which a customer can update their card and manage their
+// subscriptions.
+//
+// see https://stripe.com/docs/api#portal_session_object
+type BillingPortalSession struct {
+	ID            string   `json:"id"`
+	Customer      string   `json:"customer"`
+	Configuration string   `json:"configuration,omitempty"`
+	ReturnURL     string   `json:"return_url,omitempty"`
+	URL           string   `json:"url"`
+	Created       UnixTime `json:"created"`
+	Livemode      bool     `json:"livemode"`
+}
+
+// BillingPortalSessionClient encapsulates operations for creating customer
+// portal sessions using the Stripe REST API.
+type BillingPortalSessionClient struct{}
+
+// Creates a new customer portal session for the given customer. Redirect the
+// customer to the session's URL; they are returned to returnURL when they
+// leave the portal. The configuration is the ID of the portal configuration
+// to use, or empty for the default.
+//
+// see https://stripe.com/docs/api#create_portal_session
+func (BillingPortalSessionClient) Create(customerID, returnURL, configuration string) (*BillingPortalSession, error) {
+	values := url.Values{"customer": {customerID}}
+	if returnURL != "" {
+		values.Add("return_url", returnURL)
+	}
+	if configuration != "" {
+		values.Add("configuration", configuration)
+	}
+	res := &BillingPortalSession{}
+	return res, query("POST", "/billing_portal/sessions", values, res)
+}
+
+// BillingPortalConfiguration controls the features and business information
+// shown in the customer portal.
+//
+// see https://stripe.com/docs/api#portal_configuration_object
+type BillingPortalConfiguration struct {
+	ID               string                       `json:"id"`
+	Active           bool                         `json:"active"`
+	IsDefault        bool                         `json:"is_default"`
+	BusinessProfile  BillingPortalBusinessProfile `json:"business_profile"`
+	DefaultReturnURL string                       `json:"default_return_url,omitempty"`
+	Features         BillingPortalFeatures        `json:"features"`
+	Created          UnixTime                     `json:"created"`
+	Updated          UnixTime                     `json:"updated"`
+	Livemode         bool                         `json:"livemode"`
+	Metadata         map[string]string            `json:"metadata,omitempty"`
+}
+
+// BillingPortalBusinessProfile is the business information shown in the
+// customer portal.
+type BillingPortalBusinessProfile struct {
+	Headline          string `json:"headline,omitempty"`
+	PrivacyPolicyURL  string `json:"privacy_policy_url,omitempty"`
+	TermsOfServiceURL string `json:"terms_of_service_url,omitempty"`
+}
+
+// BillingPortalFeatures are the features available to customers in the
+// customer portal.
+type BillingPortalFeatures struct {
+	// Updating the customer's email, address and other details. The details
+	// that can be updated are listed in AllowedUpdates.
+	CustomerUpdate BillingPortalCustomerUpdate `json:"customer_update"`
+
+	// Viewing past invoices.
+	InvoiceHistory BillingPortalFeature `json:"invoice_history"`
+
+	// Updating the customer's card or other payment method.
+	PaymentMethodUpdate BillingPortalFeature `json:"payment_method_update"`
+
+	// Canceling subscriptions.
+	SubscriptionCancel BillingPortalSubscriptionCancel `json:"subscription_cancel"`
+
+	// Switching plans and changing quantities.
+	SubscriptionUpdate BillingPortalSubscriptionUpdate `json:"subscription_update"`
+}
+
+// BillingPortalFeature is a customer portal feature that can only be enabled
+// or disabled.
+type BillingPortalFeature struct {
+	Enabled bool `json:"enabled"`
+}
+
+// BillingPortalCustomerUpdate configures which customer details can be
+// updated in the customer portal, such as "email", "address" or "phone".
+type BillingPortalCustomerUpdate struct {
+	Enabled        bool     `json:"enabled"`
+	AllowedUpdates []string `json:"allowed_updates,omitempty"`
+}
+
+// BillingPortalSubscriptionCancel configures subscription cancellation in the
+// customer portal.
+type BillingPortalSubscriptionCancel struct {
+	Enabled bool `json:"enabled"`
+
+	// PortalCancelImmediately or PortalCancelAtPeriodEnd.
+	Mode string `json:"mode,omitempty"`
+
+	// How to prorate subscriptions canceled immediately.
+	ProrationBehavior string `json:"proration_behavior,omitempty"`
+}
+
+// BillingPortalSubscriptionUpdate configures plan switching in the customer
+// portal.
+type BillingPortalSubscriptionUpdate struct {
+	Enabled bool `json:"enabled"`
+
+	// What can be changed: "price", "quantity" and "promotion_code".
+	DefaultAllowedUpdates []string `json:"default_allowed_updates,omitempty"`
+
+	ProrationBehavior string `json:"proration_behavior,omitempty"`
+
+	// The products, and their prices, that customers can switch between.
+	Products []*BillingPortalProduct `json:"products,omitempty"`
+}
+
+// BillingPortalProduct is a product, and the prices of it, that customers can
+// switch to in the customer portal.
+type BillingPortalProduct struct {
+	Product string   `json:"product"`
+	Prices  []string `json:"prices"`
+}
+
+// BillingPortalConfigurationParams encapsulates options for creating and
+// updating Billing Portal Configurations.
+type BillingPortalConfigurationParams struct {
+	// (Optional) The business information shown in the portal.
+	BusinessProfile *BillingPortalBusinessProfile
+
+	// (Optional) The URL customers are returned to when a session does not
+	// set one.
+	DefaultReturnURL string
+
+	// The features available in the portal. Required when creating. When set,
+	// every feature is sent, so features left disabled are turned off.
+	Features *BillingPortalFeatures
+
+	// (Optional) Whether the configuration can be used for new sessions. Only
+	// used when updating.
+	Active *bool
+
+	Metadata map[string]string
+}
+
+// BillingPortalConfigurationClient encapsulates operations for creating,
+// updating and querying customer portal configurations using the Stripe REST
+// API.
+type BillingPortalConfigurationClient struct{}
+
+// Creates a new Billing Portal Configuration.
+//
+// see https://stripe.com/docs/api#create_portal_configuration
+func (c BillingPortalConfigurationClient) Create(params *BillingPortalConfigurationParams) (*BillingPortalConfiguration, error) {
+	res := &BillingPortalConfiguration{}
+	return res, query("POST", "/billing_portal/configurations", c.values(params), res)
+}
+
+// Retrieves the Billing Portal Configuration with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_portal_configuration
+func (BillingPortalConfigurationClient) Get(id string) (*BillingPortalConfiguration, error) {
+	res := &BillingPortalConfiguration{}
+	return res, query("GET", "/billing_portal/configurations/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Billing Portal Configuration with the given ID.
+//
+// see https://stripe.com/docs/api#update_portal_configuration
+func (c BillingPortalConfigurationClient) Update(id string, params *BillingPortalConfigurationParams) (*BillingPortalConfiguration, error) {
+	res := &BillingPortalConfiguration{}
+	return res, query("POST", "/billing_portal/configurations/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Returns a list of your Billing Portal Configurations.
+//
+// see https://stripe.com/docs/api#list_portal_configurations
+func (BillingPortalConfigurationClient) List(limit int, before, after string) ([]*BillingPortalConfiguration, bool, error) {
+	var data []*BillingPortalConfiguration
+	list, err := queryList("/billing_portal/configurations", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (BillingPortalConfigurationClient) values(params *BillingPortalConfigurationParams) url.Values {
+	values := make(url.Values)
+	if p := params.BusinessProfile; p != nil {
+		if p.Headline != "" {
+			values.Add("business_profile[headline]", p.Headline)
+		}
+		if p.PrivacyPolicyURL != "" {
+			values.Add("business_profile[privacy_policy_url]", p.PrivacyPolicyURL)
+		}
+		if p.TermsOfServiceURL != "" {
+			values.Add("business_profile[terms_of_service_url]", p.TermsOfServiceURL)
+		}
+	}
+	if params.DefaultReturnURL != "" {
+		values.Add("default_return_url", params.DefaultReturnURL)
+	}
+	if f := params.Features; f != nil {
+		appendPortalFeatures(values, f)
+	}
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+func appendPortalFeatures(values url.Values, f *BillingPortalFeatures) {
+	values.Add("features[customer_update][enabled]", strconv.FormatBool(f.CustomerUpdate.Enabled))
+	for _, u := range f.CustomerUpdate.AllowedUpdates {
+		values.Add("features[customer_update][allowed_updates][]", u)
+	}
+	values.Add("features[invoice_history][enabled]", strconv.FormatBool(f.InvoiceHistory.Enabled))
+	values.Add("features[payment_method_update][enabled]", strconv.FormatBool(f.PaymentMethodUpdate.Enabled))
+
+	values.Add("features[subscription_cancel][enabled]", strconv.FormatBool(f.SubscriptionCancel.Enabled))
+	if f.SubscriptionCancel.Mode != "" {
+		values.Add("features[subscription_cancel][mode]", f.SubscriptionCancel.Mode)
+	}
+	if f.SubscriptionCancel.ProrationBehavior != "" {
+		values.Add("features[subscription_cancel][proration_behavior]", f.SubscriptionCancel.ProrationBehavior)
+	}
+
+	values.Add("features[subscription_update][enabled]", strconv.FormatBool(f.SubscriptionUpdate.Enabled))
+	for _, u := range f.SubscriptionUpdate.DefaultAllowedUpdates {
+		values.Add("features[subscription_update][default_allowed_updates][]", u)
+	}
+	if f.SubscriptionUpdate.ProrationBehavior != "" {
+		values.Add("features[subscription_update][proration_behavior]", f.SubscriptionUpdate.ProrationBehavior)
+	}
+	for i, p := range f.SubscriptionUpdate.Products {
+		prefix := fmt.Sprintf("features[subscription_update][products][%d]", i)
+		values.Add(prefix+"[product]", p.Product)
+		for _, price := range p.Prices {
+			values.Add(prefix+"[prices][]", price)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateBillingPortalSession will test that a portal session is created
+// for the customer, returning the portal URL.
+func TestCreateBillingPortalSession(t *testing.T) {
+	req, done := mockServer(`{"id": "bps_1", "customer": "cus_1", "return_url": "https://example.com/account", "url": "https://billing.stripe.com/session/bps_1"}`)
+	defer done()
+
+	session, err := BillingPortalSessions.Create("cus_1", "https://example.com/account", "bpc_1")
+	if err != nil {
+		t.Errorf("Expected Billing Portal Session, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/billing_portal/sessions" {
+		t.Errorf("Expected POST /v1/billing_portal/sessions, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"customer":      "cus_1",
+		"return_url":    "https://example.com/account",
+		"configuration": "bpc_1",
+	})
+	if session.URL != "https://billing.stripe.com/session/bps_1" {
+		t.Errorf("Expected portal URL, got %q", session.URL)
+	}
+}
+
+// TestCreateBillingPortalConfiguration will test that every feature is sent,
+// with its options, when creating a portal configuration.
+func TestCreateBillingPortalConfiguration(t *testing.T) {
+	req, done := mockServer(`{"id": "bpc_1", "active": true, "features": {"invoice_history": {"enabled": true}, "subscription_cancel": {"enabled": true, "mode": "at_period_end"}}}`)
+	defer done()
+
+	config, err := BillingPortalConfigurations.Create(&BillingPortalConfigurationParams{
+		BusinessProfile: &BillingPortalBusinessProfile{Headline: "Vandelay Industries"},
+		Features: &BillingPortalFeatures{
+			InvoiceHistory:     BillingPortalFeature{Enabled: true},
+			SubscriptionCancel: BillingPortalSubscriptionCancel{Enabled: true, Mode: PortalCancelAtPeriodEnd},
+			SubscriptionUpdate: BillingPortalSubscriptionUpdate{
+				Enabled:               true,
+				DefaultAllowedUpdates: []string{"price"},
+				Products:              []*BillingPortalProduct{{Product: "prod_1", Prices: []string{"price_1", "price_2"}}},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Billing Portal Configuration, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/billing_portal/configurations" {
+		t.Errorf("Expected POST /v1/billing_portal/configurations, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"business_profile[headline]":                               "Vandelay Industries",
+		"features[customer_update][enabled]":                       "false",
+		"features[invoice_history][enabled]":                       "true",
+		"features[payment_method_update][enabled]":                 "false",
+		"features[subscription_cancel][enabled]":                   "true",
+		"features[subscription_cancel][mode]":                      "at_period_end",
+		"features[subscription_update][enabled]":                   "true",
+		"features[subscription_update][default_allowed_updates][]": "price",
+		"features[subscription_update][products][0][product]":      "prod_1",
+	})
+	if got := req.Form["features[subscription_update][products][0][prices][]"]; len(got) != 2 {
+		t.Errorf("Expected 2 prices, got %v", got)
+	}
+	if !config.Features.InvoiceHistory.Enabled || config.Features.SubscriptionCancel.Mode != PortalCancelAtPeriodEnd {
+		t.Errorf("Expected invoice history and cancellation at period end, got %+v", config.Features)
+	}
+}
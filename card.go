@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -18,25 +20,100 @@ const (
 	UnknownCard     = "Unknown"
 )
 
+// Card Funding Types
+const (
+	FundingCredit  = "credit"
+	FundingDebit   = "debit"
+	FundingPrepaid = "prepaid"
+	FundingUnknown = "unknown"
+)
+
 // Card represents details about a Credit Card entered into Stripe.
 type Card struct {
-	ID                string `json:"id"`
-	Name              string `json:"name,omitempty"`
-	Type              string `json:"type"`
-	ExpMonth          int    `json:"exp_month"`
-	ExpYear           int    `json:"exp_year"`
-	Last4             string `json:"last4"`
-	Fingerprint       string `json:"fingerprint"`
-	Country           string `json:"country,omitempty"`
-	Address1          string `json:"address_line1,omitempty"`
-	Address2          string `json:"address_line2,omitempty"`
-	AddressCountry    string `json:"address_country,omitempty"`
-	AddressState      string `json:"address_state,omitempty"`
-	AddressZip        string `json:"address_zip,omitempty"`
-	AddressLine1Check string `json:"address_line1_check,omitempty"`
-	AddressZipCheck   string `json:"address_zip_check,omitempty"`
-	CVCCheck          string `json:"cvc_check,omitempty"`
-	Customer          string `json:"customer,omitempty"`
+	ID                string  `json:"id"`
+	Name              string  `json:"name,omitempty"`
+	Type              string  `json:"type"`
+	Brand             string  `json:"brand,omitempty"`
+	Funding           string  `json:"funding,omitempty"`
+	ExpMonth          int     `json:"exp_month"`
+	ExpYear           int     `json:"exp_year"`
+	Last4             string  `json:"last4"`
+	Fingerprint       string  `json:"fingerprint"`
+	Country           string  `json:"country,omitempty"`
+	Address           Address `json:"-"`
+	AddressLine1Check string  `json:"address_line1_check,omitempty"`
+	AddressZipCheck   string  `json:"address_zip_check,omitempty"`
+	CVCCheck          string  `json:"cvc_check,omitempty"`
+	Customer          string  `json:"customer,omitempty"`
+}
+
+// Expired returns true if the card's expiration month has passed, according
+// to the package Clock. Cards expire at the end of their expiration month.
+func (c *Card) Expired() bool {
+	now := _clock.Now().UTC()
+	return c.ExpYear < now.Year() || (c.ExpYear == now.Year() && c.ExpMonth < int(now.Month()))
+}
+
+// cardAddress is the flat form of a card's billing address used by the
+// Stripe API.
+type cardAddress struct {
+	Line1   string `json:"address_line1,omitempty"`
+	Line2   string `json:"address_line2,omitempty"`
+	City    string `json:"address_city,omitempty"`
+	State   string `json:"address_state,omitempty"`
+	Zip     string `json:"address_zip,omitempty"`
+	Country string `json:"address_country,omitempty"`
+}
+
+// IsPrepaid returns true if the card is a prepaid card.
+func (c *Card) IsPrepaid() bool {
+	return c.Funding == FundingPrepaid
+}
+
+// UnmarshalJSON decodes a Card, collecting its flat address_* fields into
+// the card's Address, and filling in Brand and Type from each other.
+func (c *Card) UnmarshalJSON(data []byte) error {
+	type card Card
+	aux := struct {
+		*card
+		cardAddress
+	}{card: (*card)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	// the brand is sent as type by older API versions
+	if c.Brand == "" {
+		c.Brand = c.Type
+	} else if c.Type == "" {
+		c.Type = c.Brand
+	}
+	a := aux.cardAddress
+	c.Address = Address{
+		Line1:      a.Line1,
+		Line2:      a.Line2,
+		City:       a.City,
+		State:      a.State,
+		PostalCode: a.Zip,
+		Country:    a.Country,
+	}
+	return nil
+}
+
+// MarshalJSON encodes a Card, writing its Address as the flat address_*
+// fields used by the Stripe API.
+func (c Card) MarshalJSON() ([]byte, error) {
+	type card Card
+	return json.Marshal(struct {
+		card
+		cardAddress
+	}{card(c), cardAddress{
+		Line1:   c.Address.Line1,
+		Line2:   c.Address.Line2,
+		City:    c.Address.City,
+		State:   c.Address.State,
+		Zip:     c.Address.PostalCode,
+		Country: c.Address.Country,
+	}})
 }
 
 // CardParams encapsulates options for Creating or Updating Credit Cards.
@@ -56,20 +133,34 @@ type CardParams struct {
 	// Card security code
 	CVC string
 
-	// (Optional) Billing address line 1
-	Address1 string
-
-	// (Optional) Billing address line 2
-	Address2 string
-
-	// (Optional) Billing address country
-	AddressCountry string
+	// (Optional) Billing address
+	Address *Address
+}
 
-	// (Optional) Billing address state
-	AddressState string
+// Errors returned by CardParams.Validate when the card, or a field required
+// to create a new card, is missing.
+var (
+	ErrCardRequired         = errors.New("stripe: a card token or card details are required")
+	ErrCardNumberRequired   = errors.New("stripe: card number is required")
+	ErrCardExpMonthRequired = errors.New("stripe: card expiration month is required")
+	ErrCardExpYearRequired  = errors.New("stripe: card expiration year is required")
+)
 
-	// (Optional) Billing address zip code
-	AddressZip string
+// Validate checks that the fields required to create a new card (the number,
+// expiration month and expiration year) are set. A nil card is rejected with
+// ErrCardRequired.
+func (c *CardParams) Validate() error {
+	switch {
+	case c == nil:
+		return ErrCardRequired
+	case c.Number == "":
+		return ErrCardNumberRequired
+	case c.ExpMonth == 0:
+		return ErrCardExpMonthRequired
+	case c.ExpYear == 0:
+		return ErrCardExpYearRequired
+	}
+	return nil
 }
 
 type CardClient struct{}
@@ -84,18 +175,20 @@ func (c CardClient) path(customerID, cardID string) string {
 
 func (c CardClient) Create(customerID, token string, card *CardParams) (*Card, error) {
 	params := make(url.Values)
+	res := &Card{}
 	if token != "" {
 		params.Add("card", token)
+	} else if err := card.Validate(); err != nil {
+		return res, err
 	} else {
-		appendCardParams(params, false, card)
+		appendCardParams(params, card)
 	}
-	res := &Card{}
 	return res, query("POST", c.path(customerID, ""), params, res)
 }
 
 func (c CardClient) Update(customerID, cardID string, card *CardParams) (*Card, error) {
 	params := make(url.Values)
-	appendCardParams(params, false, card)
+	appendCardFields(params, "", card)
 	res := &Card{}
 	return res, query("POST", c.path(customerID, cardID), params, res)
 }
@@ -112,12 +205,92 @@ func (c CardClient) Get(customerID, cardID string) (*Card, error) {
 }
 
 func (c CardClient) List(customerID string, limit int, before, after string) ([]*Card, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Card
-	}{}
-	err := query("GET", c.path(customerID, ""), listParams(limit, before, after), &res)
-	return res.Data, res.More, err
+	var data []*Card
+	list, err := queryList(c.path(customerID, ""), listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+// ReplaceDefault adds the card in token to the customer with the given ID,
+// makes it the customer's default card, and then deletes the previous default
+// card. If any step fails, the steps already taken are undone (on a best
+// effort basis) so the customer keeps its previous default card.
+func (c CardClient) ReplaceDefault(customerID, token string) (*Card, error) {
+	cust, err := Customers.Get(customerID)
+	if err != nil {
+		return &Card{}, err
+	}
+	// Only a default card is replaced; any other default source is kept.
+	var oldID string
+	if cust.DefaultSource != nil && cust.DefaultSource.IsCard() {
+		oldID = cust.DefaultSource.ID()
+	}
+
+	card, err := c.Create(customerID, token, nil)
+	if err != nil {
+		return card, err
+	}
+	if _, err := Customers.Update(customerID, &CustomerParams{DefaultCard: card.ID}); err != nil {
+		c.Delete(customerID, card.ID)
+		return card, err
+	}
+	if oldID == "" || oldID == card.ID {
+		return card, nil
+	}
+	if _, err := c.Delete(customerID, oldID); err != nil {
+		Customers.Update(customerID, &CustomerParams{DefaultCard: oldID})
+		c.Delete(customerID, card.ID)
+		return card, err
+	}
+	return card, nil
+}
+
+// appendCardParams adds the details of a new card to values, nested under
+// card[...] as expected by every endpoint that accepts a new card (tokens,
+// charges, customers, subscriptions and customer cards).
+func appendCardParams(values url.Values, c *CardParams) {
+	appendCardFields(values, "card", c)
+}
+
+// appendCardFields adds the card fields to values, nested under the given
+// prefix. An empty prefix adds the fields at the top level, as expected when
+// updating an existing card.
+func appendCardFields(values url.Values, prefix string, c *CardParams) {
+	p := func(s string) string {
+		if prefix != "" {
+			return prefix + "[" + s + "]"
+		}
+		return s
+	}
+	if c.Number != "" {
+		values.Add(p("number"), c.Number)
+	}
+	if c.ExpMonth != 0 {
+		values.Add(p("exp_month"), strconv.Itoa(c.ExpMonth))
+	}
+	if c.ExpYear != 0 {
+		values.Add(p("exp_year"), strconv.Itoa(c.ExpYear))
+	}
+	if c.Name != "" {
+		values.Add(p("name"), c.Name)
+	}
+	if c.CVC != "" {
+		values.Add(p("cvc"), c.CVC)
+	}
+	if a := c.Address; a != nil {
+		fields := []struct{ key, value string }{
+			{"address_line1", a.Line1},
+			{"address_line2", a.Line2},
+			{"address_city", a.City},
+			{"address_state", a.State},
+			{"address_zip", a.PostalCode},
+			{"address_country", a.Country},
+		}
+		for _, f := range fields {
+			if f.value != "" {
+				values.Add(p(f.key), f.value)
+			}
+		}
+	}
 }
 
 // IsLuhnValid uses the Luhn Algorithm (also known as the Mod 10 algorithm) to
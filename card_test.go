@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"encoding/json"
+	"net/url"
 	"testing"
 )
 
@@ -38,3 +40,205 @@ func TestLuhn(t *testing.T) {
 		}
 	}
 }
+
+// TestValidateCardParams will test that a card missing any of its required
+// fields is rejected before being sent to Stripe.
+func TestValidateCardParams(t *testing.T) {
+	tests := []struct {
+		Card CardParams
+		Err  error
+	}{
+		{CardParams{Number: "4242424242424242", ExpMonth: 5, ExpYear: 2020}, nil},
+		{CardParams{ExpMonth: 5, ExpYear: 2020}, ErrCardNumberRequired},
+		{CardParams{Number: "4242424242424242", ExpYear: 2020}, ErrCardExpMonthRequired},
+		{CardParams{Number: "4242424242424242", ExpMonth: 5}, ErrCardExpYearRequired},
+	}
+
+	for _, test := range tests {
+		if err := test.Card.Validate(); err != test.Err {
+			t.Errorf("Expected Error %v for %+v, got %v", test.Err, test.Card, err)
+		}
+	}
+
+	_, err := Tokens.Create(&CardParams{Number: "4242424242424242", ExpMonth: 5})
+	if err != ErrCardExpYearRequired {
+		t.Errorf("Expected Token Error %v, got %v", ErrCardExpYearRequired, err)
+	}
+
+	// a card is created from either a token or card details
+	if _, err := Cards.Create("cus_1", "", nil); err != ErrCardRequired {
+		t.Errorf("Expected Card Error %v, got %v", ErrCardRequired, err)
+	}
+}
+
+// TestAppendCardParams will test that new cards are encoded with all of their
+// fields nested under card[...], and that card updates are encoded as
+// top-level params.
+func TestAppendCardParams(t *testing.T) {
+	card := &CardParams{
+		Name:     "George Costanza",
+		Number:   "4242424242424242",
+		ExpMonth: 5,
+		ExpYear:  2020,
+		CVC:      "123",
+	}
+	want := map[string]string{
+		"name":      "George Costanza",
+		"number":    "4242424242424242",
+		"exp_month": "5",
+		"exp_year":  "2020",
+		"cvc":       "123",
+	}
+
+	nested := make(url.Values)
+	appendCardParams(nested, card)
+	flat := make(url.Values)
+	appendCardFields(flat, "", card)
+
+	for k, v := range want {
+		if got := nested.Get("card[" + k + "]"); got != v {
+			t.Errorf("Expected card[%s]=%s, got %q", k, v, got)
+		}
+		if got := flat.Get(k); got != v {
+			t.Errorf("Expected %s=%s, got %q", k, v, got)
+		}
+	}
+	if len(nested) != len(want) || len(flat) != len(want) {
+		t.Errorf("Expected %d params, got %d nested and %d flat", len(want), len(nested), len(flat))
+	}
+}
+
+// TestCreateCardNested will test that a new card added to a customer is sent
+// nested under card[...], as the customer cards endpoint expects.
+func TestCreateCardNested(t *testing.T) {
+	req, done := mockServer(`{"id": "card_1", "last4": "4242"}`)
+	defer done()
+
+	_, err := Cards.Create("cus_1", "", &CardParams{Number: "4242424242424242", ExpMonth: 5, ExpYear: 2020})
+	if err != nil {
+		t.Errorf("Expected Card, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/customers/cus_1/cards" {
+		t.Errorf("Expected path /v1/customers/cus_1/cards, got %s", req.Path)
+	}
+	if got := req.Form.Get("card[number]"); got != "4242424242424242" {
+		t.Errorf("Expected card[number] param, got %q", got)
+	}
+	if _, ok := req.Form["number"]; ok {
+		t.Errorf("Expected no top-level number param")
+	}
+}
+
+// TestDecodeCardAddress will test that a card's flat billing address fields
+// are decoded into its Address, and encoded back to the same fields.
+func TestDecodeCardAddress(t *testing.T) {
+	data := `{"id":"card_1","country":"US","address_line1":"129 W 81st St","address_city":"New York","address_zip":"10024","address_country":"US"}`
+
+	card := Card{}
+	if err := json.Unmarshal([]byte(data), &card); err != nil {
+		t.Errorf("Expected Card decoded, got Error %s", err.Error())
+		return
+	}
+	want := Address{Line1: "129 W 81st St", City: "New York", PostalCode: "10024", Country: "US"}
+	if card.Address != want {
+		t.Errorf("Expected Card Address %+v, got %+v", want, card.Address)
+	}
+	if card.Country != "US" || card.ID != "card_1" {
+		t.Errorf("Expected Card card_1 from US, got %+v", card)
+	}
+
+	out, _ := json.Marshal(card)
+	again := Card{}
+	json.Unmarshal(out, &again)
+	if again.Address != want {
+		t.Errorf("Expected re-encoded Card Address %+v, got %+v (%s)", want, again.Address, out)
+	}
+}
+
+// TestReplaceDefaultCard will test that a new card is added and made the
+// default before the old default card is deleted.
+func TestReplaceDefaultCard(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_1":                   `{"id": "cus_1", "default_card": "card_old"}`,
+		"POST /v1/customers/cus_1/cards":            `{"id": "card_new"}`,
+		"POST /v1/customers/cus_1":                  `{"id": "cus_1", "default_card": "card_new"}`,
+		"DELETE /v1/customers/cus_1/cards/card_old": `{"id": "card_old", "deleted": true}`,
+	})
+	defer done()
+
+	card, err := Cards.ReplaceDefault("cus_1", "tok_1")
+	if err != nil {
+		t.Errorf("Expected Card, got Error %s", err.Error())
+		return
+	}
+	if card.ID != "card_new" {
+		t.Errorf("Expected Card card_new, got %s", card.ID)
+	}
+	var steps []string
+	for _, r := range *reqs {
+		steps = append(steps, r.Method+" "+r.Path)
+	}
+	want := []string{
+		"GET /v1/customers/cus_1",
+		"POST /v1/customers/cus_1/cards",
+		"POST /v1/customers/cus_1",
+		"DELETE /v1/customers/cus_1/cards/card_old",
+	}
+	if len(steps) != len(want) {
+		t.Errorf("Expected requests %v, got %v", want, steps)
+		return
+	}
+	for i := range want {
+		if steps[i] != want[i] {
+			t.Errorf("Expected request %d to be %s, got %s", i, want[i], steps[i])
+		}
+	}
+	if got := (*reqs)[2].Form.Get("default_card"); got != "card_new" {
+		t.Errorf("Expected default_card card_new, got %q", got)
+	}
+}
+
+// TestReplaceDefaultCardBankAccount will test that a default source that is
+// not a card is kept when a new default card is added.
+func TestReplaceDefaultCardBankAccount(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_1":        `{"id": "cus_1", "default_source": "ba_1"}`,
+		"POST /v1/customers/cus_1/cards": `{"id": "card_new"}`,
+		"POST /v1/customers/cus_1":       `{"id": "cus_1", "default_card": "card_new"}`,
+	})
+	defer done()
+
+	if _, err := Cards.ReplaceDefault("cus_1", "tok_1"); err != nil {
+		t.Errorf("Expected Card, got Error %s", err.Error())
+	}
+	for _, r := range *reqs {
+		if r.Method == "DELETE" {
+			t.Errorf("Expected bank account to be kept, got %s %s", r.Method, r.Path)
+		}
+	}
+}
+
+// TestReplaceDefaultCardRollback will test that the new card is removed again
+// if it cannot be made the customer's default card.
+func TestReplaceDefaultCardRollback(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_1":                   `{"id": "cus_1", "default_card": "card_old"}`,
+		"POST /v1/customers/cus_1/cards":            `{"id": "card_new"}`,
+		"DELETE /v1/customers/cus_1/cards/card_new": `{"id": "card_new", "deleted": true}`,
+	})
+	defer done()
+
+	if _, err := Cards.ReplaceDefault("cus_1", "tok_1"); err == nil {
+		t.Errorf("Expected Error when the default card cannot be updated")
+	}
+	last := (*reqs)[len(*reqs)-1]
+	if last.Method != "DELETE" || last.Path != "/v1/customers/cus_1/cards/card_new" {
+		t.Errorf("Expected new card to be deleted, got %s %s", last.Method, last.Path)
+	}
+	for _, r := range *reqs {
+		if r.Path == "/v1/customers/cus_1/cards/card_old" {
+			t.Errorf("Expected old card to be kept, got %s %s", r.Method, r.Path)
+		}
+	}
+}
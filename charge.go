@@ -1,8 +1,13 @@
 package stripe
 
 import (
+	"errors"
 	"net/url"
 	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
 )
 
 // ISO 3-digit Currency Codes for major currencies (not the full list).
@@ -21,23 +26,147 @@ const (
 //
 // see https://stripe.com/docs/api#charge_object
 type Charge struct {
-	ID                 string            `json:"id"`
-	Description        string            `json:"description,omitempty"`
-	Amount             int               `json:"amount"`
-	Card               *Card             `json:"card"`
-	Currency           string            `json:"currency"`
-	Created            UnixTime          `json:"created"`
-	Customer           string            `json:"customer,omitempty"`
-	Invoice            string            `json:"invoice,omitempty"`
-	Paid               bool              `json:"paid"`
-	Refunded           bool              `json:"refunded,omitempty"`
-	AmountRefunded     int               `json:"amount_refunded,omitempty"`
-	BalanceTransaction string            `json:"balance_transaction"`
-	Dispute            *Dispute          `json:"dispute,omitempty"`
-	FailureMessage     string            `json:"failure_message,omitempty"`
-	FailureCode        string            `json:"failure_code,omitempty"`
-	Metadata           map[string]string `json:"metadata,omitempty"`
-	Livemode           bool              `json:"livemode"`
+	ID                   string            `json:"id"`
+	Description          string            `json:"description,omitempty"`
+	StatementDescription string            `json:"statement_description,omitempty"`
+	Amount               int               `json:"amount"`
+	Card                 *Card             `json:"card"`
+	Source               *PaymentSource    `json:"source,omitempty"`
+	Currency             string            `json:"currency"`
+	Created              UnixTime          `json:"created"`
+	Customer             string            `json:"customer,omitempty"`
+	Invoice              string            `json:"invoice,omitempty"`
+	Paid                 bool              `json:"paid"`
+	Refunded             bool              `json:"refunded,omitempty"`
+	AmountRefunded       int               `json:"amount_refunded,omitempty"`
+	Refunds              []*Refund         `json:"refunds,omitempty"`
+	BalanceTransaction   string            `json:"balance_transaction"`
+	Dispute              *Dispute          `json:"dispute,omitempty"`
+	FailureMessage       string            `json:"failure_message,omitempty"`
+	FailureCode          string            `json:"failure_code,omitempty"`
+	Outcome              *ChargeOutcome    `json:"outcome,omitempty"`
+	Level3               *Level3           `json:"level3,omitempty"`
+	ReceiptEmail         string            `json:"receipt_email,omitempty"`
+	ReceiptNumber        string            `json:"receipt_number,omitempty"`
+	ReceiptURL           string            `json:"receipt_url,omitempty"`
+	Shipping             *ShippingDetails  `json:"shipping,omitempty"`
+	Destination          string            `json:"destination,omitempty"`
+	OnBehalfOf           string            `json:"on_behalf_of,omitempty"`
+	TransferData         *TransferData     `json:"transfer_data,omitempty"`
+	Transfer             string            `json:"transfer,omitempty"`
+	TransferGroup        string            `json:"transfer_group,omitempty"`
+	ApplicationFee       string            `json:"application_fee,omitempty"`
+	ApplicationFeeAmount int               `json:"application_fee_amount,omitempty"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+	Livemode             bool              `json:"livemode"`
+}
+
+// Charge Outcome Types
+const (
+	OutcomeAuthorized     = "authorized"
+	OutcomeManualReview   = "manual_review"
+	OutcomeIssuerDeclined = "issuer_declined"
+	OutcomeBlocked        = "blocked"
+	OutcomeInvalid        = "invalid"
+)
+
+// Charge Outcome Network Statuses
+const (
+	NetworkApproved              = "approved_by_network"
+	NetworkDeclined              = "declined_by_network"
+	NetworkNotSent               = "not_sent_to_network"
+	NetworkReversedAfterApproval = "reversed_after_approval"
+)
+
+// Charge Outcome Risk Levels
+const (
+	RiskNormal      = "normal"
+	RiskElevated    = "elevated"
+	RiskHighest     = "highest"
+	RiskNotAssessed = "not_assessed"
+	RiskUnknown     = "unknown"
+)
+
+// ChargeOutcome describes whether a charge was authorized, and if not, why,
+// along with Radar's assessment of its risk.
+//
+// see https://stripe.com/docs/api#charge_object-outcome
+type ChargeOutcome struct {
+	Type          string `json:"type"`
+	NetworkStatus string `json:"network_status"`
+
+	// A code for why the charge was declined or blocked, such as
+	// "highest_risk_level" or a decline code like "insufficient_funds".
+	Reason string `json:"reason,omitempty"`
+
+	RiskLevel string `json:"risk_level,omitempty"`
+
+	// Radar's risk score, from 0 to 100. Only set on accounts with Radar
+	// for Fraud Teams.
+	RiskScore int `json:"risk_score,omitempty"`
+
+	// The ID of the Radar rule that blocked the charge or placed it in
+	// review, if any.
+	Rule string `json:"rule,omitempty"`
+
+	// A human-readable description of the outcome, for the merchant.
+	SellerMessage string `json:"seller_message"`
+}
+
+// TransferData describes the funds of a destination charge that are
+// transferred to a connected account.
+type TransferData struct {
+	// The ID of the connected account the funds are transferred to.
+	Destination string `json:"destination"`
+
+	// The amount transferred, in cents. When creating a charge, zero
+	// transfers the full amount, less the application fee.
+	Amount int `json:"amount,omitempty"`
+}
+
+// Refund Reasons
+const (
+	RefundDuplicate           = "duplicate"
+	RefundFraudulent          = "fraudulent"
+	RefundRequestedByCustomer = "requested_by_customer"
+)
+
+// Refund represents a full or partial refund of a Charge.
+//
+// see https://stripe.com/docs/api#refund_object
+type Refund struct {
+	ID                   string            `json:"id,omitempty"`
+	Amount               int               `json:"amount"`
+	Currency             string            `json:"currency"`
+	Created              UnixTime          `json:"created"`
+	Charge               string            `json:"charge,omitempty"`
+	BalanceTransaction   string            `json:"balance_transaction"`
+	Description          string            `json:"description,omitempty"`
+	StatementDescription string            `json:"statement_description,omitempty"`
+	ReceiptNumber        string            `json:"receipt_number,omitempty"`
+	Reason               string            `json:"reason,omitempty"`
+	Metadata             map[string]string `json:"metadata,omitempty"`
+}
+
+// RefundParams encapsulates options for refunding a Charge.
+type RefundParams struct {
+	// (Optional) The amount to refund, in cents. Defaults to the amount of
+	// the charge that has not been refunded.
+	Amount *int
+
+	// (Optional) Why the charge is refunded, such as RefundFraudulent.
+	// Refunding as fraudulent also helps Radar block similar payments.
+	Reason string
+
+	// (Optional) Whether to refund the application fee taken by the
+	// platform, in proportion to the amount refunded.
+	RefundApplicationFee bool
+
+	// (Optional) Whether to reverse the transfer to the connected account,
+	// in proportion to the amount refunded.
+	ReverseTransfer bool
+
+	Metadata map[string]string
 }
 
 type Dispute struct {
@@ -82,12 +211,163 @@ type ChargeParams struct {
 	Capture *bool
 
 	// An arbitrary string to be displayed alongside your company name on your
-	// customer's credit card statement. This may be up to 15 characters.
+	// customer's credit card statement. This may be up to 15 characters, and
+	// the whole statement descriptor may be up to 22. Use
+	// SetStatementDescriptor to fit a dynamic suffix within both limits.
 	StatementDescription string
 
+	// (Optional) The email address to send the charge's receipt to when the
+	// charge succeeds. Receipts are not emailed in test mode.
+	ReceiptEmail string
+
+	// (Optional) Where the order paid for is shipped.
+	Shipping *ShippingDetails
+
+	// (Optional) The ID of a connected account to transfer the charge's
+	// funds to. Prefer TransferData, which also allows transferring only
+	// part of the amount; only one of the two may be set.
+	Destination string
+
+	// (Optional) The connected account and amount to transfer the charge's
+	// funds to, making a destination charge.
+	TransferData *TransferData
+
+	// (Optional) The ID of the connected account the charge is made on
+	// behalf of, which becomes the settlement merchant and determines the
+	// statement descriptor and fees.
+	OnBehalfOf string
+
+	// (Optional) Groups the charge with the Transfers paid from it, as when
+	// splitting one payment between several connected accounts.
+	TransferGroup string
+
+	// (Optional) Itemized order data for card charges to businesses.
+	Level3 *Level3
+
+	// (Optional) The amount, in cents, to keep from the amount transferred
+	// by a destination charge as the platform's fee, so it requires
+	// Destination or TransferData. The resulting fee's ID is the charge's
+	// ApplicationFee.
+	ApplicationFeeAmount int
+
+	// (Optional) Whether to refuse to charge prepaid cards. The card is looked
+	// up before charging, and ErrPrepaidCardBlocked returned if it is prepaid.
+	BlockPrepaid bool
+
 	Metadata map[string]string
 }
 
+// ErrPrepaidCardBlocked is returned when creating a Charge with BlockPrepaid
+// set for a prepaid card.
+var ErrPrepaidCardBlocked = errors.New("stripe: prepaid cards are not accepted")
+
+// checkPrepaid looks up the card a charge would be made to, returning
+// ErrPrepaidCardBlocked if it is prepaid. Card details are exchanged for a
+// token to find out, and the token is returned to be charged in their place.
+func checkPrepaid(params *ChargeParams) (string, error) {
+	var card *Card
+	token := params.Token
+	switch {
+	case params.Card != nil:
+		tok, err := Tokens.Create(params.Card)
+		if err != nil {
+			return "", err
+		}
+		token, card = tok.ID, tok.Card
+	case params.Token != "":
+		tok, err := Tokens.Get(params.Token)
+		if err != nil {
+			return "", err
+		}
+		card = tok.Card
+	case params.Customer == "":
+		return "", &ParamError{"customer", "a card, token or customer is required"}
+	default:
+		cust, err := Customers.Get(params.Customer)
+		if err != nil {
+			return "", err
+		}
+		// Only a card can be prepaid; a default bank account or Source is
+		// left for Stripe to charge.
+		if src := cust.DefaultSource; src != nil && src.IsCard() {
+			card = src.Card
+			if !src.Expanded() {
+				if card, err = Cards.Get(params.Customer, src.ID()); err != nil {
+					return "", err
+				}
+			}
+		}
+	}
+	if card != nil && card.IsPrepaid() {
+		return "", ErrPrepaidCardBlocked
+	}
+	return token, nil
+}
+
+// Statement descriptor lengths. Card networks display at most 22 characters,
+// and at the pinned API version a charge's statement_description is appended
+// to the account's statement descriptor, separated by a space.
+const (
+	MaxStatementDescriptorLength  = 22
+	MaxStatementDescriptionLength = 15
+)
+
+// ErrStatementDescriptionTooLong is returned when creating a Charge whose
+// StatementDescription exceeds MaxStatementDescriptionLength characters.
+var ErrStatementDescriptionTooLong = errors.New("stripe: statement description is longer than 15 characters")
+
+// characters Stripe rejects in statement descriptors
+var statementDescriptorReplacer = strings.NewReplacer("<", "", ">", "", `"`, "", "'", "", `\`, "", "*", "")
+
+// StatementDescriptorSuffix returns the suffix to send as a Charge's
+// StatementDescription so that, appended to the account's statement
+// descriptor prefix, it fits within MaxStatementDescriptorLength characters.
+// Disallowed characters are removed and the suffix is truncated to fit. An
+// empty string is returned if the prefix leaves no room for a suffix.
+func StatementDescriptorSuffix(prefix, suffix string) string {
+	budget := MaxStatementDescriptorLength - utf8.RuneCountInString(prefix) - 1
+	if budget > MaxStatementDescriptionLength {
+		budget = MaxStatementDescriptionLength
+	}
+	if budget <= 0 {
+		return ""
+	}
+	suffix = strings.TrimSpace(statementDescriptorReplacer.Replace(suffix))
+	if r := []rune(suffix); len(r) > budget {
+		suffix = strings.TrimSpace(string(r[:budget]))
+	}
+	return suffix
+}
+
+// SetStatementDescriptor sets the StatementDescription to the given suffix,
+// fitted to the account's statement descriptor prefix.
+func (p *ChargeParams) SetStatementDescriptor(prefix, suffix string) {
+	p.StatementDescription = StatementDescriptorSuffix(prefix, suffix)
+}
+
+// ChargeListParams encapsulates options for listing Charges.
+type ChargeListParams struct {
+	// (Optional) Only return charges of the customer with the given ID.
+	Customer string
+
+	// (Optional) Only return charges created within the given range.
+	Created *DateFilter
+
+	// (Optional) Only return charges in the given transfer group.
+	TransferGroup string
+
+	// (Optional) Only return charges that were, or were not, paid, refunded
+	// or captured.
+	Paid     *bool
+	Refunded *bool
+	Captured *bool
+
+	// (Optional) The page size and cursors, as for other lists.
+	Limit  int
+	Before string
+	After  string
+}
+
 // ChargeClient encapsulates operations for creating, updating, deleting and
 // querying charges using the Stripe REST API.
 type ChargeClient struct{}
@@ -97,6 +377,20 @@ type ChargeClient struct{}
 // see https://stripe.com/docs/api#create_charge
 func (ChargeClient) Create(params *ChargeParams) (*Charge, error) {
 	charge := Charge{}
+	if params.Card != nil {
+		if err := params.Card.Validate(); err != nil {
+			return &charge, err
+		}
+	}
+	if utf8.RuneCountInString(params.StatementDescription) > MaxStatementDescriptionLength {
+		return &charge, ErrStatementDescriptionTooLong
+	}
+	if params.Destination != "" && params.TransferData != nil {
+		return &charge, &ParamError{"destination", "cannot be set with transfer_data"}
+	}
+	if params.ApplicationFeeAmount > 0 && params.Destination == "" && params.TransferData == nil {
+		return &charge, &ParamError{"application_fee_amount", "requires destination or transfer_data"}
+	}
 	values := url.Values{
 		"amount":   {strconv.Itoa(params.Amount)},
 		"currency": {params.Currency},
@@ -111,13 +405,53 @@ func (ChargeClient) Create(params *ChargeParams) (*Charge, error) {
 	if params.StatementDescription != "" {
 		values.Add("statement_description", params.StatementDescription)
 	}
+	if params.ReceiptEmail != "" {
+		values.Add("receipt_email", params.ReceiptEmail)
+	}
+	if params.Shipping != nil {
+		appendShippingDetails(values, "shipping", params.Shipping)
+	}
+	if params.Destination != "" {
+		values.Add("destination", params.Destination)
+	}
+	if td := params.TransferData; td != nil {
+		values.Add("transfer_data[destination]", td.Destination)
+		if td.Amount > 0 {
+			values.Add("transfer_data[amount]", strconv.Itoa(td.Amount))
+		}
+	}
+	if params.OnBehalfOf != "" {
+		values.Add("on_behalf_of", params.OnBehalfOf)
+	}
+	if params.TransferGroup != "" {
+		values.Add("transfer_group", params.TransferGroup)
+	}
+	if params.ApplicationFeeAmount > 0 {
+		values.Add("application_fee_amount", strconv.Itoa(params.ApplicationFeeAmount))
+	}
+	if params.Level3 != nil {
+		appendLevel3(values, params.Level3)
+	}
 	appendMetadata(values, params.Metadata)
 
+	// check the card is not prepaid, charging a token in place of any card
+	// details that had to be exchanged for one to find out
+	card, token := params.Card, params.Token
+	if params.BlockPrepaid {
+		tok, err := checkPrepaid(params)
+		if err != nil {
+			return &charge, err
+		}
+		if tok != "" {
+			card, token = nil, tok
+		}
+	}
+
 	// add optional credit card details, if specified
-	if params.Card != nil {
-		appendCardParams(values, true, params.Card)
-	} else if len(params.Token) > 0 {
-		values.Add("card", params.Token)
+	if card != nil {
+		appendCardParams(values, card)
+	} else if len(token) > 0 {
+		values.Add("card", token)
 	} else {
 		// if no credit card is provide we need to specify the customer
 		values.Add("customer", params.Customer)
@@ -127,6 +461,23 @@ func (ChargeClient) Create(params *ChargeParams) (*Charge, error) {
 	return &charge, err
 }
 
+// ChargeUpdateParams encapsulates options for updating an existing Charge.
+type ChargeUpdateParams struct {
+	// (Optional) An arbitrary string which you can attach to a charge object.
+	Description string
+
+	// (Optional) The email address to send this charge's receipts to. Stripe
+	// sends refund receipts to the charge's receipt email, so set it before
+	// refunding to have the refund receipt delivered to a new address.
+	ReceiptEmail string
+
+	// (Optional) Where the order paid for is shipped, such as to add the
+	// tracking number once it has shipped.
+	Shipping *ShippingDetails
+
+	Metadata map[string]string
+}
+
 // Retrieves the details of a charge with the given ID.
 //
 // see https://stripe.com/docs/api#retrieve_charge
@@ -137,6 +488,28 @@ func (ChargeClient) Get(id string) (*Charge, error) {
 	return &charge, err
 }
 
+// Updates the description, receipt email, shipping details or metadata of the
+// charge with the given ID.
+//
+// see https://stripe.com/docs/api#update_charge
+func (ChargeClient) Update(id string, params *ChargeUpdateParams) (*Charge, error) {
+	values := make(url.Values)
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.ReceiptEmail != "" {
+		values.Add("receipt_email", params.ReceiptEmail)
+	}
+	if params.Shipping != nil {
+		appendShippingDetails(values, "shipping", params.Shipping)
+	}
+	appendMetadata(values, params.Metadata)
+
+	charge := Charge{}
+	err := query("POST", "/charges/"+url.QueryEscape(id), values, &charge)
+	return &charge, err
+}
+
 // Refunds a charge for the full amount.
 //
 // see https://stripe.com/docs/api#refund_charge
@@ -161,11 +534,60 @@ func (ChargeClient) RefundAmount(id string, amt int) (*Charge, error) {
 	return &charge, err
 }
 
-// Returns a list of your Charges with the specified range.
+// Refunds a charge with the given options.
+//
+// see https://stripe.com/docs/api#refund_charge
+func (ChargeClient) RefundWithParams(id string, params *RefundParams) (*Charge, error) {
+	values := make(url.Values)
+	if params.Amount != nil {
+		values.Add("amount", strconv.Itoa(*params.Amount))
+	}
+	if params.Reason != "" {
+		values.Add("reason", params.Reason)
+	}
+	if params.RefundApplicationFee {
+		values.Add("refund_application_fee", "true")
+	}
+	if params.ReverseTransfer {
+		values.Add("reverse_transfer", "true")
+	}
+	appendMetadata(values, params.Metadata)
+	charge := Charge{}
+	path := "/charges/" + url.QueryEscape(id) + "/refund"
+	err := query("POST", path, values, &charge)
+	return &charge, err
+}
+
+// Returns a list of your Charges matching the given filters, or the most
+// recent Charges if params is nil.
 //
 // see https://stripe.com/docs/api#list_charges
-func (c ChargeClient) List(limit int, before, after string) ([]*Charge, bool, error) {
-	return c.list("", limit, before, after)
+func (ChargeClient) List(params *ChargeListParams) ([]*Charge, bool, error) {
+	if params == nil {
+		params = &ChargeListParams{}
+	}
+	var data []*Charge
+	values := listParams(params.Limit, params.Before, params.After)
+	if params.Customer != "" {
+		values.Add("customer", params.Customer)
+	}
+	if params.Created != nil {
+		params.Created.appendValues(values, "created")
+	}
+	if params.TransferGroup != "" {
+		values.Add("transfer_group", params.TransferGroup)
+	}
+	if params.Paid != nil {
+		values.Add("paid", strconv.FormatBool(*params.Paid))
+	}
+	if params.Refunded != nil {
+		values.Add("refunded", strconv.FormatBool(*params.Refunded))
+	}
+	if params.Captured != nil {
+		values.Add("captured", strconv.FormatBool(*params.Captured))
+	}
+	list, err := queryList("/charges", values, &data)
+	return data, list.More, err
 }
 
 // Returns a list of your Charges with the given Customer ID.
@@ -175,15 +597,113 @@ func (c ChargeClient) CustomerList(id string, limit int, before, after string) (
 	return c.list(id, limit, before, after)
 }
 
+// Export sends every charge created between start and end to out, closing
+// it when done. The range is split into the given number of shards with
+// ShardDateRange, and the shards are listed concurrently by at most workers
+// goroutines. Every worker waits out the Retry-After period of the most
+// recent rate limited response before sending a request, and rate limited
+// requests are retried with backoff. Charges are sent in no particular
+// order, so out must be read concurrently. Export stops at the first other
+// error, or a request still rate limited after its retries, and returns it.
+func (c ChargeClient) Export(start, end time.Time, shards, workers int, out chan<- *Charge) error {
+	defer close(out)
+	if workers < 1 {
+		workers = 1
+	}
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+	)
+	jobs := make(chan *DateFilter)
+	stop := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for created := range jobs {
+				err := c.walkCreated(created, 0, stop, func(data []*Charge) bool {
+					for _, charge := range data {
+						select {
+						case out <- charge:
+						case <-stop:
+							return false
+						}
+					}
+					return true
+				})
+				if err != nil {
+					once.Do(func() {
+						firstErr = err
+						close(stop)
+					})
+				}
+			}
+		}()
+	}
+
+feed:
+	for _, created := range ShardDateRange(start, end, shards) {
+		select {
+		case jobs <- created:
+		case <-stop:
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return firstErr
+}
+
+// listCreated returns every charge created within the given range, walking
+// all pages of the list with the given page size.
+func (c ChargeClient) listCreated(created *DateFilter, size int) ([]*Charge, error) {
+	var all []*Charge
+	err := c.walkCreated(created, size, nil, func(data []*Charge) bool {
+		all = append(all, data...)
+		return true
+	})
+	if err != nil {
+		return nil, err
+	}
+	return all, nil
+}
+
+// walkCreated calls fn with each page of the charges created within the
+// given range, until fn returns false or there are no more pages. Pages of
+// the given size (see pageSize) are requested with paced, which stops
+// waiting to retry when stop is closed.
+func (ChargeClient) walkCreated(created *DateFilter, size int, stop <-chan struct{}, fn func([]*Charge) bool) error {
+	after := ""
+	for {
+		var (
+			data []*Charge
+			list *ListObject
+		)
+		params := listParams(pageSize(size), "", after)
+		created.appendValues(params, "created")
+		err := paced("GET", "/charges", stop, func() (err error) {
+			data = nil
+			list, err = queryList("/charges", params, &data)
+			return err
+		})
+		if err != nil {
+			return err
+		}
+		if !fn(data) || !list.More || len(data) == 0 {
+			return nil
+		}
+		after = data[len(data)-1].ID
+	}
+}
+
 func (ChargeClient) list(id string, limit int, before, after string) ([]*Charge, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Charge
-	}{}
+	var data []*Charge
 	params := listParams(limit, before, after)
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/charges", params, &res)
-	return res.Data, res.More, err
+	list, err := queryList("/charges", params, &data)
+	return data, list.More, err
 }
@@ -1,6 +1,10 @@
 package stripe
 
 import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sync"
 	"testing"
 	"time"
 )
@@ -88,7 +92,7 @@ func TestCreateChargeCustomer(t *testing.T) {
 	// This customer should have a credit card setup
 	cust, _ := Customers.Create(&cust4)
 	defer Customers.Delete(cust.ID)
-	if cust.DefaultCard == "" {
+	if cust.DefaultSourceID() == "" {
 		t.Errorf("Cannot test charging a customer with no pre-defined Card")
 		return
 	}
@@ -147,3 +151,618 @@ func TestRefundCharge(t *testing.T) {
 		return
 	}
 }
+
+// TestUpdateChargeReceiptEmail will test that a receipt email can be set on an
+// existing charge, and that receipt details are decoded on the charge and its
+// refunds.
+func TestUpdateChargeReceiptEmail(t *testing.T) {
+	req, done := mockServer(`{
+		"id": "ch_1",
+		"receipt_email": "jenny@example.com",
+		"receipt_number": "1234-5678",
+		"statement_description": "CALZONE",
+		"refunds": [{"amount": 100, "receipt_number": "1234-5679", "description": "damaged"}]
+	}`)
+	defer done()
+
+	charge, err := Charges.Update("ch_1", &ChargeUpdateParams{ReceiptEmail: "jenny@example.com"})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/charges/ch_1" {
+		t.Errorf("Expected POST /v1/charges/ch_1, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("receipt_email"); got != "jenny@example.com" {
+		t.Errorf("Expected receipt_email param, got %q", got)
+	}
+	if charge.ReceiptNumber != "1234-5678" || charge.StatementDescription != "CALZONE" {
+		t.Errorf("Expected Charge receipt details, got %+v", charge)
+	}
+	if len(charge.Refunds) != 1 || charge.Refunds[0].ReceiptNumber != "1234-5679" {
+		t.Errorf("Expected Refund Receipt Number 1234-5679, got %+v", charge.Refunds)
+	}
+}
+
+// TestCreateChargeReceiptEmail will test that a receipt email is sent when
+// creating a charge, and that the receipt URL is decoded.
+func TestCreateChargeReceiptEmail(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "paid": true, "receipt_email": "jenny@example.com", "receipt_url": "https://pay.stripe.com/receipts/ch_1"}`)
+	defer done()
+
+	charge, err := Charges.Create(&ChargeParams{
+		Amount:       400,
+		Currency:     USD,
+		Token:        "tok_1",
+		ReceiptEmail: "jenny@example.com",
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("receipt_email"); got != "jenny@example.com" {
+		t.Errorf("Expected receipt_email param, got %q", got)
+	}
+	if charge.ReceiptURL != "https://pay.stripe.com/receipts/ch_1" {
+		t.Errorf("Expected Charge receipt URL, got %q", charge.ReceiptURL)
+	}
+}
+
+// TestCreateChargeShipping will test that shipping details are sent when
+// creating a charge, and decoded on the charge.
+func TestCreateChargeShipping(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "shipping": {"name": "Jenny Rosen", "carrier": "USPS", "tracking_number": "9400", "address": {"line1": "1 Main St", "postal_code": "94107"}}}`)
+	defer done()
+
+	charge, err := Charges.Create(&ChargeParams{
+		Amount:   400,
+		Currency: USD,
+		Token:    "tok_1",
+		Shipping: &ShippingDetails{
+			Name:    "Jenny Rosen",
+			Phone:   "555-1234",
+			Address: &Address{Line1: "1 Main St", PostalCode: "94107"},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"shipping[name]":                 "Jenny Rosen",
+		"shipping[phone]":                "555-1234",
+		"shipping[address][line1]":       "1 Main St",
+		"shipping[address][postal_code]": "94107",
+	})
+	if _, ok := req.Form["shipping[carrier]"]; ok {
+		t.Errorf("Expected no carrier param, got %q", req.Form.Get("shipping[carrier]"))
+	}
+	s := charge.Shipping
+	if s == nil || s.TrackingNumber != "9400" || s.Address == nil || s.Address.PostalCode != "94107" {
+		t.Errorf("Expected shipping with tracking number 9400 to 94107, got %+v", s)
+	}
+}
+
+// TestCreateDestinationCharge will test that the connected account and
+// amount to transfer are sent when creating a destination charge.
+func TestCreateDestinationCharge(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "on_behalf_of": "acct_1", "transfer_data": {"destination": "acct_1", "amount": 877}, "transfer": "tr_1"}`)
+	defer done()
+
+	charge, err := Charges.Create(&ChargeParams{
+		Amount:       1000,
+		Currency:     USD,
+		Token:        "tok_1",
+		TransferData: &TransferData{Destination: "acct_1", Amount: 877},
+		OnBehalfOf:   "acct_1",
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"transfer_data[destination]": "acct_1",
+		"transfer_data[amount]":      "877",
+		"on_behalf_of":               "acct_1",
+	})
+	if _, ok := req.Form["destination"]; ok {
+		t.Errorf("Expected no destination param, got %q", req.Form.Get("destination"))
+	}
+	if td := charge.TransferData; td == nil || td.Destination != "acct_1" || td.Amount != 877 || charge.Transfer != "tr_1" {
+		t.Errorf("Expected transfer of 877 to acct_1, got %+v", td)
+	}
+}
+
+// TestCreateChargeDestinationAndTransferData will test that a charge with
+// both a destination and transfer data is rejected before it is sent.
+func TestCreateChargeDestinationAndTransferData(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{"POST /v1/charges": `{"id": "ch_1"}`})
+	defer done()
+
+	_, err := Charges.Create(&ChargeParams{
+		Amount:       1000,
+		Currency:     USD,
+		Token:        "tok_1",
+		Destination:  "acct_1",
+		TransferData: &TransferData{Destination: "acct_2"},
+	})
+	if perr, ok := err.(*ParamError); !ok || perr.Param != "destination" {
+		t.Errorf("Expected destination ParamError, got %v", err)
+	}
+	if len(*reqs) != 0 {
+		t.Errorf("Expected no request to be sent, got %d", len(*reqs))
+	}
+}
+
+// TestCreateChargeApplicationFee will test that the platform's fee is sent
+// when creating a destination charge, and the resulting fee decoded, and that
+// a fee without a destination is rejected.
+func TestCreateChargeApplicationFee(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "application_fee": "fee_1", "application_fee_amount": 123}`)
+	defer done()
+
+	_, err := Charges.Create(&ChargeParams{
+		Amount:               1000,
+		Currency:             USD,
+		Token:                "tok_1",
+		ApplicationFeeAmount: 123,
+	})
+	if perr, ok := err.(*ParamError); !ok || perr.Param != "application_fee_amount" {
+		t.Errorf("Expected application_fee_amount ParamError, got %v", err)
+	}
+
+	charge, err := Charges.Create(&ChargeParams{
+		Amount:               1000,
+		Currency:             USD,
+		Token:                "tok_1",
+		TransferData:         &TransferData{Destination: "acct_1"},
+		ApplicationFeeAmount: 123,
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("application_fee_amount"); got != "123" {
+		t.Errorf("Expected param application_fee_amount=123, got %q", got)
+	}
+	if charge.ApplicationFee != "fee_1" || charge.ApplicationFeeAmount != 123 {
+		t.Errorf("Expected application fee fee_1 of 123, got %q of %d", charge.ApplicationFee, charge.ApplicationFeeAmount)
+	}
+}
+
+// TestChargeOutcome will test that the outcome of a blocked charge is
+// decoded.
+func TestChargeOutcome(t *testing.T) {
+	_, done := mockServer(`{"id": "ch_1", "paid": false, "outcome": {"type": "blocked", "network_status": "not_sent_to_network", "reason": "highest_risk_level", "risk_level": "highest", "risk_score": 91, "seller_message": "Stripe blocked this payment as too risky."}}`)
+	defer done()
+
+	charge, err := Charges.Get("ch_1")
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	o := charge.Outcome
+	if o == nil {
+		t.Errorf("Expected Charge outcome")
+		return
+	}
+	if o.Type != OutcomeBlocked || o.NetworkStatus != NetworkNotSent || o.RiskLevel != RiskHighest || o.RiskScore != 91 {
+		t.Errorf("Expected blocked outcome of highest risk, got %+v", o)
+	}
+	if o.Reason != "highest_risk_level" || o.SellerMessage == "" {
+		t.Errorf("Expected outcome reason and seller message, got %+v", o)
+	}
+}
+
+// TestCreateChargeLevel3 will test that Level 3 data is sent with a charge,
+// with its line items indexed.
+func TestCreateChargeLevel3(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "level3": {"merchant_reference": "PO-1", "line_items": [{"product_code": "SKU1"}, {"product_code": "SKU2"}]}}`)
+	defer done()
+
+	charge, err := Charges.Create(&ChargeParams{
+		Amount:   2700,
+		Currency: USD,
+		Token:    "tok_1",
+		Level3: &Level3{
+			MerchantReference: "PO-1",
+			ShippingAmount:    200,
+			LineItems: []*Level3LineItem{
+				{ProductCode: "SKU1", ProductDescription: "Widget", UnitCost: 1000, Quantity: 2, TaxAmount: 100},
+				{ProductCode: "SKU2", ProductDescription: "Gadget", UnitCost: 500, Quantity: 1, DiscountAmount: 100},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"level3[merchant_reference]":             "PO-1",
+		"level3[shipping_amount]":                "200",
+		"level3[line_items][0][product_code]":    "SKU1",
+		"level3[line_items][0][quantity]":        "2",
+		"level3[line_items][0][tax_amount]":      "100",
+		"level3[line_items][1][product_code]":    "SKU2",
+		"level3[line_items][1][unit_cost]":       "500",
+		"level3[line_items][1][discount_amount]": "100",
+	})
+	if charge.Level3 == nil || len(charge.Level3.LineItems) != 2 {
+		t.Errorf("Expected Level 3 data with 2 line items, got %+v", charge.Level3)
+	}
+}
+
+// TestChargeSource will test that the source of a charge keeps its type.
+func TestChargeSource(t *testing.T) {
+	_, done := mockServer(`{"id": "ch_1", "source": {"object": "source", "id": "src_1", "type": "alipay", "alipay": {}}}`)
+	defer done()
+
+	charge, err := Charges.Get("ch_1")
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if s := charge.Source; s == nil || s.Source == nil || s.Source.Type != SourceTypeAlipay || s.Card != nil {
+		t.Errorf("Expected alipay Source, got %+v", s)
+	}
+}
+
+// TestStatementDescriptorSuffix will test that a dynamic suffix is cleaned
+// and truncated to fit alongside the account's statement descriptor.
+func TestStatementDescriptorSuffix(t *testing.T) {
+	for _, test := range []struct {
+		Prefix, Suffix, Expected string
+	}{
+		{"ACME", "Order 1234", "Order 1234"},
+		{"ACME", "Order <1234> for \"Jerry\"", "Order 1234 for"},
+		{"ACME CORPORATION", "Order 1234", "Order"},
+		{"ACME INTERNATIONAL LTD", "Order 1234", ""},
+	} {
+		got := StatementDescriptorSuffix(test.Prefix, test.Suffix)
+		if got != test.Expected {
+			t.Errorf("Expected suffix %q for prefix %q, got %q", test.Expected, test.Prefix, got)
+		}
+		if n := len(test.Prefix) + 1 + len(got); got != "" && n > MaxStatementDescriptorLength {
+			t.Errorf("Expected statement descriptor of at most %d characters, got %d", MaxStatementDescriptorLength, n)
+		}
+	}
+}
+
+// TestCreateChargeStatementDescriptionTooLong will test that a statement
+// description that Stripe would truncate is rejected before sending.
+func TestCreateChargeStatementDescriptionTooLong(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1"}`)
+	defer done()
+
+	params := ChargeParams{
+		Amount:               400,
+		Currency:             USD,
+		Token:                "tok_1",
+		StatementDescription: "Calzone and a Big Salad",
+	}
+	if _, err := Charges.Create(&params); err != ErrStatementDescriptionTooLong {
+		t.Errorf("Expected ErrStatementDescriptionTooLong, got %v", err)
+	}
+	if req.Method != "" {
+		t.Errorf("Expected no request to be sent, got %s %s", req.Method, req.Path)
+	}
+
+	params.SetStatementDescriptor("ACME", "Calzone and a Big Salad")
+	if _, err := Charges.Create(&params); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+	}
+	if got := req.Form.Get("statement_description"); got != "Calzone and a B" {
+		t.Errorf("Expected statement_description %q, got %q", "Calzone and a B", got)
+	}
+}
+
+// TestShardDateRange will test that a range is split into consecutive,
+// non-overlapping shards covering it exactly.
+func TestShardDateRange(t *testing.T) {
+	shards := ShardDateRange(time.Unix(1000, 0), time.Unix(1999, 0), 4)
+	if len(shards) != 4 {
+		t.Errorf("Expected 4 shards, got %d", len(shards))
+		return
+	}
+	next := int64(1000)
+	for _, s := range shards {
+		if s.Gte.Unix() != next {
+			t.Errorf("Expected shard to start at %d, got %d", next, s.Gte.Unix())
+		}
+		next = s.Lte.Unix() + 1
+	}
+	if next != 2000 {
+		t.Errorf("Expected shards to end at 1999, got %d", next-1)
+	}
+
+	if shards := ShardDateRange(time.Unix(1000, 0), time.Unix(1001, 0), 4); len(shards) != 2 {
+		t.Errorf("Expected 2 shards for a 2 second range, got %d", len(shards))
+	}
+}
+
+// TestExportCharges will test that each shard is listed, and that the charges
+// of every shard are sent to the channel.
+func TestExportCharges(t *testing.T) {
+	var mu sync.Mutex
+	gtes := make(map[string]bool)
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		mu.Lock()
+		gtes[r.Form.Get("created[gte]")] = true
+		mu.Unlock()
+		id := "ch_" + r.Form.Get("created[gte]")
+		if r.Form.Get("starting_after") == "" {
+			fmt.Fprintf(w, `{"object": "list", "has_more": true, "data": [{"id": "%s_1"}]}`, id)
+		} else {
+			fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [{"id": "%s_2"}]}`, id)
+		}
+	})
+	defer done()
+
+	out := make(chan *Charge)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- Charges.Export(time.Unix(1000, 0), time.Unix(1399, 0), 4, 2, out)
+	}()
+	ids := make(map[string]bool)
+	for charge := range out {
+		ids[charge.ID] = true
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+	}
+	if len(gtes) != 4 || !gtes["1000"] || !gtes["1300"] {
+		t.Errorf("Expected 4 shards from 1000 to 1300, got %v", gtes)
+	}
+	if len(ids) != 8 || !ids["ch_1300_2"] {
+		t.Errorf("Expected 8 Charges from 4 shards, got %v", ids)
+	}
+}
+
+// TestExportChargesError will test that an export stops and returns the
+// first error.
+func TestExportChargesError(t *testing.T) {
+	_, done := mockRouter(map[string]string{})
+	defer done()
+
+	out := make(chan *Charge)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- Charges.Export(time.Unix(1000, 0), time.Unix(1399, 0), 4, 2, out)
+	}()
+	for range out {
+	}
+	if err, ok := (<-errc).(*Error); !ok || err.Detail.Type != "invalid_request_error" {
+		t.Errorf("Expected invalid_request_error, got %v", err)
+	}
+}
+
+// TestExportChargesRateLimited will test that a rate limited page is
+// retried after backing off, rather than aborting the export.
+func TestExportChargesRateLimited(t *testing.T) {
+	prev := rateLimitBackoff
+	rateLimitBackoff = 10 * time.Millisecond
+	defer func() { rateLimitBackoff = prev }()
+
+	var (
+		mu      sync.Mutex
+		limited bool
+		reqs    int
+	)
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		mu.Lock()
+		defer mu.Unlock()
+		reqs++
+		if !limited {
+			limited = true
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"type": "rate_limit_error", "message": "Too many requests"}}`))
+			return
+		}
+		fmt.Fprintf(w, `{"object": "list", "has_more": false, "data": [{"id": "ch_%s"}]}`, r.Form.Get("created[gte]"))
+	})
+	defer done()
+
+	key := StatsKey{"charges", "GET"}
+	before := Stats()[key]
+	out := make(chan *Charge)
+	errc := make(chan error, 1)
+	go func() {
+		errc <- Charges.Export(time.Unix(1000, 0), time.Unix(1399, 0), 2, 2, out)
+	}()
+	ids := make(map[string]bool)
+	for charge := range out {
+		ids[charge.ID] = true
+	}
+	if err := <-errc; err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+	}
+	if len(ids) != 2 || reqs != 3 {
+		t.Errorf("Expected 2 Charges in 3 requests, got %v in %d", ids, reqs)
+	}
+	if got := Stats()[key].Retries - before.Retries; got != 1 {
+		t.Errorf("Expected 1 retry, got %d", got)
+	}
+}
+
+// TestCardBrandAndFunding will test that the brand is decoded under either
+// name, along with the funding type.
+func TestCardBrandAndFunding(t *testing.T) {
+	for _, data := range []string{
+		`{"id": "card_1", "type": "Visa", "funding": "prepaid", "country": "US"}`,
+		`{"id": "card_1", "brand": "Visa", "funding": "prepaid", "country": "US"}`,
+	} {
+		card := Card{}
+		if err := json.Unmarshal([]byte(data), &card); err != nil {
+			t.Errorf("Expected Card, got Error %s", err.Error())
+			continue
+		}
+		if card.Brand != Visa || card.Type != Visa || card.Country != "US" || !card.IsPrepaid() {
+			t.Errorf("Expected prepaid Visa from US, got %+v", card)
+		}
+	}
+}
+
+// TestCreateChargeBlockPrepaid will test that prepaid cards are refused
+// before charging, and that card details are charged as a token once they
+// have been checked.
+func TestCreateChargeBlockPrepaid(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/tokens/tok_prepaid": `{"id": "tok_prepaid", "card": {"id": "card_1", "brand": "Visa", "funding": "prepaid"}}`,
+		"POST /v1/tokens":            `{"id": "tok_debit", "card": {"id": "card_2", "brand": "Visa", "funding": "debit"}}`,
+		"POST /v1/charges":           `{"id": "ch_1", "paid": true}`,
+	})
+	defer done()
+
+	params := ChargeParams{Amount: 400, Currency: USD, Token: "tok_prepaid", BlockPrepaid: true}
+	if _, err := Charges.Create(&params); err != ErrPrepaidCardBlocked {
+		t.Errorf("Expected ErrPrepaidCardBlocked, got %v", err)
+	}
+	if len(*reqs) != 1 {
+		t.Errorf("Expected only the token to be retrieved, got %d requests", len(*reqs))
+	}
+
+	params = ChargeParams{Amount: 400, Currency: USD, Card: charge1.Card, BlockPrepaid: true}
+	if _, err := Charges.Create(&params); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	last := (*reqs)[len(*reqs)-1]
+	if last.Path != "/v1/charges" || last.Form.Get("card") != "tok_debit" {
+		t.Errorf("Expected charge of tok_debit, got %s %v", last.Path, last.Form)
+	}
+	if _, ok := last.Form["card[number]"]; ok {
+		t.Errorf("Expected card details not to be sent with the charge")
+	}
+}
+
+// TestCreateChargeBlockPrepaidNoSource will test that a charge with nothing
+// to charge is rejected before the card is looked up.
+func TestCreateChargeBlockPrepaidNoSource(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{})
+	defer done()
+
+	_, err := Charges.Create(&ChargeParams{Amount: 400, Currency: USD, BlockPrepaid: true})
+	if perr, ok := err.(*ParamError); !ok || perr.Param != "customer" {
+		t.Errorf("Expected customer ParamError, got %v", err)
+	}
+	if len(*reqs) != 0 {
+		t.Errorf("Expected no request to be sent, got %d", len(*reqs))
+	}
+}
+
+// TestCreateChargeBlockPrepaidCustomer will test that a customer's default
+// card is checked, without retrieving it again when it is expanded, and that
+// a default bank account is not looked up as a card.
+func TestCreateChargeBlockPrepaidCustomer(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_card":              `{"id": "cus_card", "default_source": "card_1"}`,
+		"GET /v1/customers/cus_card/cards/card_1": `{"id": "card_1", "funding": "prepaid"}`,
+		"GET /v1/customers/cus_expanded":          `{"id": "cus_expanded", "default_source": {"id": "card_2", "object": "card", "funding": "prepaid"}}`,
+		"GET /v1/customers/cus_bank":              `{"id": "cus_bank", "default_source": "ba_1"}`,
+		"POST /v1/charges":                        `{"id": "ch_1", "paid": true}`,
+	})
+	defer done()
+
+	for _, cust := range []string{"cus_card", "cus_expanded"} {
+		params := ChargeParams{Amount: 400, Currency: USD, Customer: cust, BlockPrepaid: true}
+		if _, err := Charges.Create(&params); err != ErrPrepaidCardBlocked {
+			t.Errorf("Expected ErrPrepaidCardBlocked for %s, got %v", cust, err)
+		}
+	}
+	if len(*reqs) != 3 {
+		t.Errorf("Expected the expanded card not to be retrieved, got %d requests", len(*reqs))
+	}
+
+	params := ChargeParams{Amount: 400, Currency: USD, Customer: "cus_bank", BlockPrepaid: true}
+	if _, err := Charges.Create(&params); err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+	}
+	for _, r := range *reqs {
+		if r.Path == "/v1/customers/cus_bank/cards/ba_1" {
+			t.Errorf("Expected bank account not to be retrieved as a card")
+		}
+	}
+}
+
+// TestListChargesFilters will test that the created range and the paid,
+// refunded and captured filters are sent when listing charges.
+func TestListChargesFilters(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "ch_1", "paid": true, "captured": false}]}`)
+	defer done()
+
+	charges, _, err := Charges.List(&ChargeListParams{
+		Created:  &DateFilter{Gte: &UnixTime{time.Unix(1500000000, 0)}},
+		Paid:     Bool(true),
+		Captured: Bool(false),
+		Limit:    10,
+	})
+	if err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"created[gte]": "1500000000",
+		"paid":         "true",
+		"captured":     "false",
+		"limit":        "10",
+	})
+	for _, k := range []string{"refunded", "customer", "created[lte]"} {
+		if _, ok := req.Form[k]; ok {
+			t.Errorf("Expected no param %s, got %q", k, req.Form.Get(k))
+		}
+	}
+	if len(charges) != 1 {
+		t.Errorf("Expected 1 Charge, got %d", len(charges))
+	}
+}
+
+// TestListChargesNilParams will test that listing charges without params
+// sends no filters.
+func TestListChargesNilParams(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "ch_1"}]}`)
+	defer done()
+
+	charges, _, err := Charges.List(nil)
+	if err != nil {
+		t.Errorf("Expected Charges, got Error %s", err.Error())
+		return
+	}
+	if len(req.Form) != 0 {
+		t.Errorf("Expected no params, got %v", req.Form)
+	}
+	if len(charges) != 1 {
+		t.Errorf("Expected 1 Charge, got %d", len(charges))
+	}
+}
+
+// TestRefundChargeWithParams will test that the refund reason, metadata and
+// Connect options are sent when refunding a charge.
+func TestRefundChargeWithParams(t *testing.T) {
+	req, done := mockServer(`{"id": "ch_1", "refunded": true, "refunds": [{"id": "re_1", "amount": 400, "reason": "fraudulent"}]}`)
+	defer done()
+
+	charge, err := Charges.RefundWithParams("ch_1", &RefundParams{
+		Amount:               Int(400),
+		Reason:               RefundFraudulent,
+		RefundApplicationFee: true,
+		ReverseTransfer:      true,
+		Metadata:             map[string]string{"ticket": "T-1"},
+	})
+	if err != nil {
+		t.Errorf("Expected Charge, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/charges/ch_1/refund" {
+		t.Errorf("Expected POST /v1/charges/ch_1/refund, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"amount":                 "400",
+		"reason":                 "fraudulent",
+		"refund_application_fee": "true",
+		"reverse_transfer":       "true",
+		"metadata[ticket]":       "T-1",
+	})
+	if len(charge.Refunds) != 1 || charge.Refunds[0].Reason != RefundFraudulent {
+		t.Errorf("Expected fraudulent Refund, got %+v", charge.Refunds)
+	}
+}
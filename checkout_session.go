@@ -0,0 +1,165 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Checkout Session Modes
+const (
+	CheckoutModePayment      = "payment"
+	CheckoutModeSetup        = "setup"
+	CheckoutModeSubscription = "subscription"
+)
+
+// Checkout Session Statuses
+const (
+	CheckoutSessionOpen     = "open"
+	CheckoutSessionComplete = "complete"
+	CheckoutSessionExpired  = "expired"
+)
+
+// CheckoutSession represents a customer's session on a Stripe-hosted payment
+// page. Customers are sent to URL to pay, and are redirected to the success or
+// cancel URL when they are done.
+//
+// see https://stripe.com/docs/api#checkout_session_object
+type CheckoutSession struct {
+	ID                string            `json:"id"`
+	URL               string            `json:"url,omitempty"`
+	Mode              string            `json:"mode"`
+	Status            string            `json:"status"`
+	PaymentStatus     string            `json:"payment_status"`
+	Customer          string            `json:"customer,omitempty"`
+	CustomerEmail     string            `json:"customer_email,omitempty"`
+	ClientReferenceID string            `json:"client_reference_id,omitempty"`
+	SuccessURL        string            `json:"success_url"`
+	CancelURL         string            `json:"cancel_url,omitempty"`
+	AmountSubtotal    int               `json:"amount_subtotal"`
+	AmountTotal       int               `json:"amount_total"`
+	Currency          string            `json:"currency,omitempty"`
+	PaymentIntent     string            `json:"payment_intent,omitempty"`
+	SetupIntent       string            `json:"setup_intent,omitempty"`
+	Subscription      string            `json:"subscription,omitempty"`
+	ExpiresAt         UnixTime          `json:"expires_at"`
+	Livemode          bool              `json:"livemode"`
+	Metadata          map[string]string `json:"metadata,omitempty"`
+}
+
+// CheckoutLineItem represents an item purchased in a Checkout Session.
+type CheckoutLineItem struct {
+	ID             string `json:"id"`
+	Description    string `json:"description"`
+	AmountSubtotal int    `json:"amount_subtotal"`
+	AmountTotal    int    `json:"amount_total"`
+	Currency       string `json:"currency"`
+	Quantity       int    `json:"quantity"`
+	Price          *Price `json:"price,omitempty"`
+}
+
+// CheckoutSessionParams encapsulates options for creating a Checkout Session.
+type CheckoutSessionParams struct {
+	// The mode of the session. Either payment, setup or subscription.
+	Mode string
+
+	// The URL the customer is sent to after completing the session.
+	SuccessURL string
+
+	// (Optional) The URL the customer is sent to if they cancel.
+	CancelURL string
+
+	// (Optional) The ID of an existing customer to pay as.
+	Customer string
+
+	// (Optional) The email address to prefill, when no customer is given.
+	CustomerEmail string
+
+	// (Optional) A reference to reconcile the session with your own records,
+	// such as a cart ID.
+	ClientReferenceID string
+
+	// (Optional) The payment method types the customer may use.
+	PaymentMethodTypes []string
+
+	// The items being purchased. Required for payment and subscription modes.
+	LineItems []*CheckoutLineItemParams
+
+	Metadata map[string]string
+}
+
+// CheckoutLineItemParams describes an item to purchase in a Checkout
+// Session.
+type CheckoutLineItemParams struct {
+	// The ID of the price to charge.
+	Price string
+
+	// The quantity to purchase.
+	Quantity int
+}
+
+// CheckoutSessionClient encapsulates operations for creating, expiring and
+// querying Checkout Sessions using the Stripe REST API.
+type CheckoutSessionClient struct{}
+
+// Creates a new Checkout Session. Redirect the customer to the returned
+// session's URL to complete the purchase.
+//
+// see https://stripe.com/docs/api#create_checkout_session
+func (CheckoutSessionClient) Create(params *CheckoutSessionParams) (*CheckoutSession, error) {
+	values := url.Values{
+		"mode":        {params.Mode},
+		"success_url": {params.SuccessURL},
+	}
+	if params.CancelURL != "" {
+		values.Add("cancel_url", params.CancelURL)
+	}
+	if params.Customer != "" {
+		values.Add("customer", params.Customer)
+	}
+	if params.CustomerEmail != "" {
+		values.Add("customer_email", params.CustomerEmail)
+	}
+	if params.ClientReferenceID != "" {
+		values.Add("client_reference_id", params.ClientReferenceID)
+	}
+	for _, typ := range params.PaymentMethodTypes {
+		values.Add("payment_method_types[]", typ)
+	}
+	for i, item := range params.LineItems {
+		values.Add(fmt.Sprintf("line_items[%d][price]", i), item.Price)
+		values.Add(fmt.Sprintf("line_items[%d][quantity]", i), strconv.Itoa(item.Quantity))
+	}
+	appendMetadata(values, params.Metadata)
+
+	res := &CheckoutSession{}
+	return res, query("POST", "/checkout/sessions", values, res)
+}
+
+// Retrieves the Checkout Session with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_checkout_session
+func (CheckoutSessionClient) Get(id string) (*CheckoutSession, error) {
+	res := &CheckoutSession{}
+	return res, query("GET", "/checkout/sessions/"+url.QueryEscape(id), nil, res)
+}
+
+// Expires the open Checkout Session with the given ID, so the customer can no
+// longer complete it.
+//
+// see https://stripe.com/docs/api#expire_checkout_session
+func (CheckoutSessionClient) Expire(id string) (*CheckoutSession, error) {
+	res := &CheckoutSession{}
+	return res, query("POST", "/checkout/sessions/"+url.QueryEscape(id)+"/expire", nil, res)
+}
+
+// Returns a list of the items purchased in the Checkout Session with the given
+// ID.
+//
+// see https://stripe.com/docs/api#checkout_session_line_items
+func (CheckoutSessionClient) ListLineItems(id string, limit int, before, after string) ([]*CheckoutLineItem, bool, error) {
+	var data []*CheckoutLineItem
+	path := "/checkout/sessions/" + url.QueryEscape(id) + "/line_items"
+	list, err := queryList(path, listParams(limit, before, after), &data)
+	return data, list.More, err
+}
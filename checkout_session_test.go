@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateCheckoutSession will test that a Checkout Session is created with
+// its line items, and that the hosted page URL is decoded.
+func TestCreateCheckoutSession(t *testing.T) {
+	req, done := mockServer(`{"id": "cs_1", "url": "https://checkout.stripe.com/pay/cs_1", "mode": "payment", "status": "open"}`)
+	defer done()
+
+	session, err := CheckoutSessions.Create(&CheckoutSessionParams{
+		Mode:       CheckoutModePayment,
+		SuccessURL: "https://example.com/success",
+		CancelURL:  "https://example.com/cancel",
+		Customer:   "cus_1",
+		LineItems: []*CheckoutLineItemParams{
+			{Price: "price_1", Quantity: 2},
+			{Price: "price_2", Quantity: 1},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Checkout Session, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/checkout/sessions" {
+		t.Errorf("Expected POST /v1/checkout/sessions, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"mode":                    CheckoutModePayment,
+		"success_url":             "https://example.com/success",
+		"customer":                "cus_1",
+		"line_items[0][price]":    "price_1",
+		"line_items[0][quantity]": "2",
+		"line_items[1][price]":    "price_2",
+	})
+	if session.URL != "https://checkout.stripe.com/pay/cs_1" || session.Status != CheckoutSessionOpen {
+		t.Errorf("Expected open Checkout Session with URL, got %+v", session)
+	}
+}
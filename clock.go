@@ -0,0 +1,50 @@
+package stripe
+
+import (
+	"time"
+)
+
+// Clock provides the current time to the package's time-dependent checks and
+// helpers, such as trial end validation, card expiry, seat change proration,
+// and the Retry-After periods reported by RateLimit, which bulk helpers such
+// as ChargeClient.Export wait out before retrying. Replace it with SetClock
+// to test time-dependent behavior deterministically.
+type Clock interface {
+	Now() time.Time
+}
+
+// systemClock is the default Clock, using the local system time.
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// the clock used by all time-dependent checks and helpers
+var _clock Clock = systemClock{}
+
+// the clock skew tolerated when checking timestamps are in the future
+var _skew = 5 * time.Minute
+
+// SetClock will override the Clock used by all time-dependent checks and
+// helpers. A nil Clock restores the system clock.
+func SetClock(c Clock) {
+	if c == nil {
+		c = systemClock{}
+	}
+	_clock = c
+}
+
+// SetClockSkew will set how far in the past a timestamp that must be in the
+// future (such as a trial end or a coupon's redeem by date) may be before it
+// is rejected, to allow for drift between this machine and Stripe. The
+// default is five minutes.
+func SetClockSkew(d time.Duration) {
+	_skew = d
+}
+
+// inFuture returns true if t is after the current time, allowing for the
+// configured clock skew.
+func inFuture(t time.Time) bool {
+	return t.After(_clock.Now().Add(-_skew))
+}
@@ -0,0 +1,105 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// fixedClock is a Clock that always returns the same time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time {
+	return time.Time(c)
+}
+
+// TestClockSkew will test that timestamps which must be in the future are
+// checked against the injected clock, allowing for the configured skew.
+func TestClockSkew(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	SetClock(fixedClock(now))
+	defer SetClock(nil)
+	defer SetClockSkew(5 * time.Minute)
+
+	req, done := mockServer(`{"id": "sub_1"}`)
+	defer done()
+
+	// a minute behind the clock is within the default skew
+	trialEnd := &UnixTime{now.Add(-time.Minute)}
+	if _, err := Subscriptions.Update("cus_1", "sub_1", &SubscriptionParams{TrialEnd: trialEnd}); err != nil {
+		t.Errorf("Expected Subscription, got Error %s", err.Error())
+	}
+	if got := req.Form.Get("trial_end"); got != "1499999940" {
+		t.Errorf("Expected param trial_end=1499999940, got %q", got)
+	}
+
+	SetClockSkew(0)
+	if _, err := Subscriptions.Update("cus_1", "sub_1", &SubscriptionParams{TrialEnd: trialEnd}); err != ErrTrialEndInPast {
+		t.Errorf("Expected ErrTrialEndInPast, got %v", err)
+	}
+	if _, err := Customers.Update("cus_1", &CustomerParams{TrialEnd: trialEnd}); err != ErrTrialEndInPast {
+		t.Errorf("Expected ErrTrialEndInPast, got %v", err)
+	}
+	if _, err := Coupons.Create(&CouponParams{Duration: DurationOnce, PercentOff: Int(10), RedeemBy: trialEnd}); err != ErrRedeemByInPast {
+		t.Errorf("Expected ErrRedeemByInPast, got %v", err)
+	}
+}
+
+// TestClockTrialEndTestClock will test that a trial end in the past is left
+// for Stripe to check for customers attached to a test clock.
+func TestClockTrialEndTestClock(t *testing.T) {
+	now := time.Unix(1500000000, 0)
+	SetClock(fixedClock(now))
+	defer SetClock(nil)
+
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_1":                `{"id": "cus_1", "test_clock": "clock_1"}`,
+		"POST /v1/customers/cus_1/subscriptions": `{"id": "sub_1"}`,
+		"POST /v1/customers":                     `{"id": "cus_2", "test_clock": "clock_1"}`,
+	})
+	defer done()
+
+	trialEnd := &UnixTime{now.Add(-time.Hour)}
+	if _, err := Subscriptions.Create("cus_1", &SubscriptionParams{Plan: "gold", TrialEnd: trialEnd}); err != nil {
+		t.Errorf("Expected Subscription, got Error %s", err.Error())
+	}
+	if _, err := Customers.Create(&CustomerParams{TestClock: "clock_1", TrialEnd: trialEnd}); err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+	}
+	var steps []string
+	for _, r := range *reqs {
+		steps = append(steps, r.Method+" "+r.Path)
+	}
+	want := []string{
+		"GET /v1/customers/cus_1",
+		"POST /v1/customers/cus_1/subscriptions",
+		"POST /v1/customers",
+	}
+	if len(steps) != len(want) {
+		t.Errorf("Expected requests %v, got %v", want, steps)
+	}
+	if got := (*reqs)[len(*reqs)-1].Form.Get("trial_end"); got != "1499996400" {
+		t.Errorf("Expected param trial_end=1499996400, got %q", got)
+	}
+}
+
+// TestCardExpired will test that card expiry is checked against the injected
+// clock, with cards valid until the end of their expiration month.
+func TestCardExpired(t *testing.T) {
+	SetClock(fixedClock(time.Date(2017, time.July, 31, 23, 0, 0, 0, time.UTC)))
+	defer SetClock(nil)
+
+	for _, test := range []struct {
+		Month, Year int
+		Expired     bool
+	}{
+		{7, 2017, false},
+		{6, 2017, true},
+		{1, 2018, false},
+		{12, 2016, true},
+	} {
+		card := Card{ExpMonth: test.Month, ExpYear: test.Year}
+		if card.Expired() != test.Expired {
+			t.Errorf("Expected card expiring %d/%d Expired %v, got %v", test.Month, test.Year, test.Expired, card.Expired())
+		}
+	}
+}
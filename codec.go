@@ -0,0 +1,33 @@
+package stripe
+
+import (
+	"encoding/json"
+)
+
+// Codec decodes JSON. Requests are form-encoded, so only responses and event
+// data are JSON. A replacement must be compatible with encoding/json: it
+// must honor json struct tags, and the json.Unmarshaler implementations of
+// this package's types.
+type Codec interface {
+	Unmarshal(data []byte, v interface{}) error
+}
+
+// jsonCodec is the default Codec, using encoding/json.
+type jsonCodec struct{}
+
+func (jsonCodec) Unmarshal(data []byte, v interface{}) error {
+	return json.Unmarshal(data, v)
+}
+
+// the codec used to decode API responses and event data
+var _codec Codec = jsonCodec{}
+
+// SetCodec will override the Codec used to decode API responses and event
+// data, for example to use a faster JSON implementation for large lists and
+// exports. A nil Codec restores encoding/json.
+func SetCodec(c Codec) {
+	if c == nil {
+		c = jsonCodec{}
+	}
+	_codec = c
+}
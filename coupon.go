@@ -1,6 +1,8 @@
 package stripe
 
 import (
+	"errors"
+	"fmt"
 	"net/url"
 	"strconv"
 )
@@ -12,22 +14,55 @@ const (
 	DurationRepeating = "repeating"
 )
 
+// ErrRedeemByInPast is returned when creating a Coupon whose redeem by date is
+// not in the future, allowing for the clock skew set with SetClockSkew.
+var ErrRedeemByInPast = errors.New("stripe: coupon redeem by must be in the future")
+
 // Coupon represents percent-off discount you might want to apply to a customer.
 //
 // see https://stripe.com/docs/api#coupon_object
 type Coupon struct {
-	ID               string            `json:"id"`
-	Duration         string            `json:"duration"`
-	AmountOff        int               `json:"amount_off,omitempty"`
-	PercentOff       int               `json:"percent_off,omitempty"`
-	DurationInMonths int               `json:"duration_in_months,omitempty"`
-	MaxRedemptions   int               `json:"max_redemptions,omitempty"`
-	RedeemBy         *UnixTime         `json:"redeem_by,omitempty"`
-	TimesRedeemed    int               `json:"times_redeemed"`
-	Livemode         bool              `json:"livemode"`
-	Created          UnixTime          `json:"created"`
-	Metadata         map[string]string `json:"metadata"`
-	Valid            bool              `json:"valid"`
+	ID               string                           `json:"id"`
+	Name             string                           `json:"name,omitempty"`
+	Duration         string                           `json:"duration"`
+	AmountOff        int                              `json:"amount_off,omitempty"`
+	Currency         string                           `json:"currency,omitempty"`
+	PercentOff       int                              `json:"percent_off,omitempty"`
+	DurationInMonths int                              `json:"duration_in_months,omitempty"`
+	MaxRedemptions   int                              `json:"max_redemptions,omitempty"`
+	RedeemBy         *UnixTime                        `json:"redeem_by,omitempty"`
+	TimesRedeemed    int                              `json:"times_redeemed"`
+	Livemode         bool                             `json:"livemode"`
+	Created          UnixTime                         `json:"created"`
+	Metadata         map[string]string                `json:"metadata"`
+	Valid            bool                             `json:"valid"`
+	AppliesTo        *CouponAppliesTo                 `json:"applies_to,omitempty"`
+	CurrencyOptions  map[string]*CouponCurrencyOption `json:"currency_options,omitempty"`
+}
+
+// amountOffIn returns the amount off the coupon takes from a bill in the given
+// currency: the AmountOff in the coupon's own currency, the amount from its
+// CurrencyOptions in another, or 0 if it has no amount in that currency.
+func (c *Coupon) amountOffIn(currency string) int {
+	if currency == c.Currency {
+		return c.AmountOff
+	}
+	if opt := c.CurrencyOptions[currency]; opt != nil {
+		return opt.AmountOff
+	}
+	return 0
+}
+
+// CouponAppliesTo restricts a Coupon to discounting only the given
+// products, by ID.
+type CouponAppliesTo struct {
+	Products []string `json:"products"`
+}
+
+// CouponCurrencyOption holds the amount off of a Coupon in an additional
+// currency.
+type CouponCurrencyOption struct {
+	AmountOff int `json:"amount_off"`
 }
 
 // CouponClient encapsulates operations for creating, updating, deleting and
@@ -41,8 +76,8 @@ type CouponParams struct {
 	ID string
 
 	// A positive integer between 1 and 100 that represents the discount the
-	// coupon will apply.
-	PercentOff int
+	// coupon will apply (required if amount_off is not passed).
+	PercentOff *int
 
 	// Specifies how long the discount will be in effect. Can be forever, once,
 	// or repeating.
@@ -50,7 +85,7 @@ type CouponParams struct {
 
 	// A positive integer representing the amount to subtract from an invoice
 	// total (required if percent_off is not passed)
-	AmountOff int
+	AmountOff *int
 
 	// Currency of the amount_off parameter (required if amount_off is passed)
 	Currency string
@@ -69,17 +104,66 @@ type CouponParams struct {
 	// applied to new customers.
 	RedeemBy *UnixTime
 
+	// (Optional) The name of the coupon shown to customers, such as on
+	// invoices. Defaults to the ID.
+	Name string
+
+	// (Optional) Restricts the coupon to the given products. By default it
+	// applies to every product.
+	AppliesTo *CouponAppliesTo
+
+	// (Optional) The amount off in other currencies, keyed by currency. Only
+	// used with AmountOff.
+	CurrencyOptions map[string]*CouponCurrencyOption
+
 	Metadata map[string]string
 }
 
+// CouponUpdateParams encapsulates options for updating an existing Coupon.
+// The discount and duration of a coupon cannot be changed once it is created.
+type CouponUpdateParams struct {
+	// (Optional) The name of the coupon shown to customers, such as on
+	// invoices.
+	Name string
+
+	Metadata map[string]string
+}
+
+// Validate checks that the Duration is one of the known durations, that
+// DurationInMonths is set if and only if the Duration is DurationRepeating,
+// and that a Currency and any CurrencyOptions are given only with AmountOff,
+// returning a *ParamError naming the invalid field.
+func (c *CouponParams) Validate() error {
+	switch c.Duration {
+	case DurationForever, DurationOnce, DurationRepeating:
+	default:
+		return &ParamError{"duration", fmt.Sprintf("%q is not one of forever, once or repeating", c.Duration)}
+	}
+	if c.Duration == DurationRepeating && c.DurationInMonths <= 0 {
+		return &ParamError{"duration_in_months", "must be positive when duration is repeating"}
+	}
+	if c.Duration != DurationRepeating && c.DurationInMonths != 0 {
+		return &ParamError{"duration_in_months", "can only be set when duration is repeating"}
+	}
+	if c.AmountOff != nil && c.Currency == "" {
+		return &ParamError{"currency", "is required with amount_off"}
+	}
+	if c.AmountOff == nil && len(c.CurrencyOptions) != 0 {
+		return &ParamError{"currency_options", "can only be set with amount_off"}
+	}
+	return nil
+}
+
 // Creates a new Coupon.
 //
 // see https://stripe.com/docs/api#create_coupon
 func (CouponClient) Create(params *CouponParams) (*Coupon, error) {
 	coupon := Coupon{}
+	if err := params.Validate(); err != nil {
+		return &coupon, err
+	}
 	values := url.Values{
-		"duration":    {params.Duration},
-		"percent_off": {strconv.Itoa(params.PercentOff)},
+		"duration": {params.Duration},
 	}
 
 	if len(params.ID) != 0 {
@@ -91,14 +175,30 @@ func (CouponClient) Create(params *CouponParams) (*Coupon, error) {
 	if params.MaxRedemptions != 0 {
 		values.Add("max_redemptions", strconv.Itoa(params.MaxRedemptions))
 	}
-
-	if params.AmountOff != 0 {
-		values.Add("amount_off", strconv.Itoa(params.AmountOff))
+	if params.PercentOff != nil {
+		values.Add("percent_off", strconv.Itoa(*params.PercentOff))
+	}
+	if params.AmountOff != nil {
+		values.Add("amount_off", strconv.Itoa(*params.AmountOff))
 		values.Add("currency", params.Currency)
 	}
+	for currency, opt := range params.CurrencyOptions {
+		values.Add(fmt.Sprintf("currency_options[%s][amount_off]", currency), strconv.Itoa(opt.AmountOff))
+	}
 	if params.RedeemBy != nil {
+		if !inFuture(params.RedeemBy.Time) {
+			return &coupon, ErrRedeemByInPast
+		}
 		values.Add("redeem_by", strconv.FormatInt(params.RedeemBy.Unix(), 10))
 	}
+	if params.Name != "" {
+		values.Add("name", params.Name)
+	}
+	if params.AppliesTo != nil {
+		for _, product := range params.AppliesTo.Products {
+			values.Add("applies_to[products][]", product)
+		}
+	}
 	appendMetadata(values, params.Metadata)
 
 	err := query("POST", "/coupons", values, &coupon)
@@ -115,6 +215,21 @@ func (CouponClient) Get(id string) (*Coupon, error) {
 	return &coupon, err
 }
 
+// Updates the name or metadata of the coupon with the given ID.
+//
+// see https://stripe.com/docs/api#update_coupon
+func (CouponClient) Update(id string, params *CouponUpdateParams) (*Coupon, error) {
+	values := make(url.Values)
+	if params.Name != "" {
+		values.Add("name", params.Name)
+	}
+	appendMetadata(values, params.Metadata)
+
+	coupon := Coupon{}
+	err := query("POST", "/coupons/"+url.QueryEscape(id), values, &coupon)
+	return &coupon, err
+}
+
 // Deletes the coupon with the given ID.
 //
 // see https://stripe.com/docs/api#delete_coupon
@@ -131,10 +246,7 @@ func (CouponClient) Delete(id string) (bool, error) {
 //
 // see https://stripe.com/docs/api#list_coupons
 func (CouponClient) List(limit int, before, after string) ([]*Coupon, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Coupon
-	}{}
-	err := query("GET", "/coupons", listParams(limit, before, after), &res)
-	return res.Data, res.More, err
+	var data []*Coupon
+	list, err := queryList("/coupons", listParams(limit, before, after), &data)
+	return data, list.More, err
 }
@@ -17,14 +17,14 @@ var (
 	// Coupon with only the required fields
 	c1 = CouponParams{
 		ID:         "test coupon 1",
-		PercentOff: 5,
+		PercentOff: Int(5),
 		Duration:   DurationOnce,
 	}
 
 	// Coupon with all required + optional fields.
 	c2 = CouponParams{
 		ID:               "test coupon 2",
-		PercentOff:       10,
+		PercentOff:       Int(10),
 		Duration:         DurationRepeating,
 		MaxRedemptions:   100,
 		DurationInMonths: 6,
@@ -53,9 +53,9 @@ func TestCreateCoupon(t *testing.T) {
 		t.Errorf("Expected Coupon MaxRedemptions %v, got %v",
 			c1.MaxRedemptions, coupon.MaxRedemptions)
 	}
-	if coupon.PercentOff != c1.PercentOff {
+	if coupon.PercentOff != *c1.PercentOff {
 		t.Errorf("Expected Coupon PercentOff %v, got %v",
-			c1.PercentOff, coupon.PercentOff)
+			*c1.PercentOff, coupon.PercentOff)
 	}
 
 	// Now try to re-create the existing coupon, which should throw an exception
@@ -67,6 +67,67 @@ func TestCreateCoupon(t *testing.T) {
 	}
 }
 
+// TestCreateCouponForProducts will test that a Coupon's name and the products
+// it applies to are sent when creating it, and parsed from the response.
+func TestCreateCouponForProducts(t *testing.T) {
+	req, done := mockServer(`{"id": "BUNDLE", "name": "Bundle Deal", "percent_off": 15, "duration": "forever",
+		"applies_to": {"products": ["prod_1", "prod_2"]}}`)
+	defer done()
+
+	coupon, err := Coupons.Create(&CouponParams{
+		ID:         "BUNDLE",
+		Name:       "Bundle Deal",
+		PercentOff: Int(15),
+		Duration:   DurationForever,
+		AppliesTo:  &CouponAppliesTo{Products: []string{"prod_1", "prod_2"}},
+	})
+	if err != nil {
+		t.Errorf("Expected Coupon, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("name"); got != "Bundle Deal" {
+		t.Errorf("Expected param name=Bundle Deal, got %q", got)
+	}
+	if got := req.Form["applies_to[products][]"]; len(got) != 2 || got[0] != "prod_1" || got[1] != "prod_2" {
+		t.Errorf("Expected applies_to products prod_1 and prod_2, got %v", got)
+	}
+	if coupon.Name != "Bundle Deal" || coupon.AppliesTo == nil || len(coupon.AppliesTo.Products) != 2 {
+		t.Errorf("Expected Coupon for 2 products, got %+v", coupon)
+	}
+}
+
+// TestCreateCouponCurrencyOptions will test that a Coupon's amount off in
+// other currencies is sent when creating it, and parsed from the response.
+func TestCreateCouponCurrencyOptions(t *testing.T) {
+	req, done := mockServer(`{"id": "5OFF", "amount_off": 500, "currency": "usd", "duration": "once",
+		"currency_options": {"eur": {"amount_off": 450}, "gbp": {"amount_off": 400}}}`)
+	defer done()
+
+	coupon, err := Coupons.Create(&CouponParams{
+		ID:        "5OFF",
+		AmountOff: Int(500),
+		Currency:  USD,
+		Duration:  DurationOnce,
+		CurrencyOptions: map[string]*CouponCurrencyOption{
+			EUR: {AmountOff: 450},
+			GBP: {AmountOff: 400},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Coupon, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"amount_off":                        "500",
+		"currency":                          "usd",
+		"currency_options[eur][amount_off]": "450",
+		"currency_options[gbp][amount_off]": "400",
+	})
+	if opt := coupon.CurrencyOptions[EUR]; opt == nil || opt.AmountOff != 450 {
+		t.Errorf("Expected eur amount off 450, got %+v", coupon.CurrencyOptions)
+	}
+}
+
 // TestRetrieveCoupon will test that we can successfully Retrieve a Coupon,
 // parse the JSON response, and that all values are populated as expected.
 //
@@ -85,9 +146,9 @@ func TestRetrieveCoupon(t *testing.T) {
 	if coupon.ID != c2.ID {
 		t.Errorf("Expected Coupon ID %s, got %s", c2.ID, coupon.ID)
 	}
-	if coupon.PercentOff != c2.PercentOff {
+	if coupon.PercentOff != *c2.PercentOff {
 		t.Errorf("Expected Coupon PercentOff %v, got %v",
-			c2.PercentOff, coupon.PercentOff)
+			*c2.PercentOff, coupon.PercentOff)
 	}
 	if coupon.Duration != c2.Duration {
 		t.Errorf("Expected Coupon Duration %v, got %v",
@@ -102,6 +163,32 @@ func TestRetrieveCoupon(t *testing.T) {
 	}
 }
 
+// TestUpdateCoupon will test that a Coupon's name and metadata are sent to the
+// coupon's endpoint, and the updated Coupon is parsed from the JSON response.
+func TestUpdateCoupon(t *testing.T) {
+	req, done := mockServer(`{"id": "25OFF", "name": "Spring Sale", "percent_off": 25, "duration": "once", "metadata": {"campaign": "spring"}}`)
+	defer done()
+
+	coupon, err := Coupons.Update("25OFF", &CouponUpdateParams{
+		Name:     "Spring Sale",
+		Metadata: map[string]string{"campaign": "spring"},
+	})
+	if err != nil {
+		t.Errorf("Expected Coupon, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/coupons/25OFF" {
+		t.Errorf("Expected POST /v1/coupons/25OFF, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"name":               "Spring Sale",
+		"metadata[campaign]": "spring",
+	})
+	if coupon.Name != "Spring Sale" || coupon.Metadata["campaign"] != "spring" {
+		t.Errorf("Expected updated Coupon, got %+v", coupon)
+	}
+}
+
 // TestDeleteCoupon will test that we can successfully remove a Coupon, parse
 // the JSON reponse, and that the deletion flag is captured as a boolean value.
 func TestDeleteCoupon(t *testing.T) {
@@ -140,3 +227,32 @@ func TestListCoupon(t *testing.T) {
 		t.Errorf("Expected 2 Coupons, got %d", len(coupons))
 	}
 }
+
+// TestCouponParamsValidate will test that invalid combinations of duration,
+// duration in months and amount off are rejected with the invalid field.
+func TestCouponParamsValidate(t *testing.T) {
+	for _, test := range []struct {
+		Params CouponParams
+		Param  string
+	}{
+		{c1, ""},
+		{c2, ""},
+		{CouponParams{Duration: "monthly", PercentOff: Int(5)}, "duration"},
+		{CouponParams{Duration: DurationOnce, PercentOff: Int(5), DurationInMonths: 3}, "duration_in_months"},
+		{CouponParams{Duration: DurationRepeating, PercentOff: Int(5)}, "duration_in_months"},
+		{CouponParams{Duration: DurationForever, AmountOff: Int(500)}, "currency"},
+		{CouponParams{Duration: DurationForever, AmountOff: Int(500), Currency: USD}, ""},
+		{CouponParams{Duration: DurationForever, PercentOff: Int(5), CurrencyOptions: map[string]*CouponCurrencyOption{EUR: {AmountOff: 450}}}, "currency_options"},
+	} {
+		err := test.Params.Validate()
+		if test.Param == "" {
+			if err != nil {
+				t.Errorf("Expected valid Coupon, got Error %s", err.Error())
+			}
+			continue
+		}
+		if perr, ok := err.(*ParamError); !ok || perr.Param != test.Param {
+			t.Errorf("Expected ParamError for %s, got %v", test.Param, err)
+		}
+	}
+}
@@ -1,32 +1,105 @@
 package stripe
 
 import (
+	"encoding/json"
 	"net/url"
 	"strconv"
 )
 
+// Customer Tax Exemptions
+const (
+	TaxExemptNone    = "none"
+	TaxExemptExempt  = "exempt"
+	TaxExemptReverse = "reverse"
+)
+
 // Customer encapsulates details about a Customer registered in Stripe.
 //
 // see https://stripe.com/docs/api#customer_object
 type Customer struct {
-	ID            string            `json:"id"`
-	Description   string            `json:"description,omitempty"`
-	Email         string            `json:"email,omitempty"`
-	Created       UnixTime          `json:"created"`
-	Balance       int               `json:"account_balance,omitempty"`
-	Currency      string            `json:"currency"`
-	Delinquent    bool              `json:"delinquent,omitempty"`
-	Cards         *CardList         `json:"cards,omitempty"`
-	Discount      *Discount         `json:"discount,omitempty"`
-	Subscriptions *SubscriptionList `json:"subscriptions,omitempty"`
-	Livemode      bool              `json:"livemode"`
-	DefaultCard   string            `json:"default_card"`
-	Metadata      map[string]string `json:"metadata,omitempty"`
-}
-
-type ListObject struct {
-	Count int  `json:"total_count"`
-	More  bool `json:"has_more"`
+	ID                  string                   `json:"id"`
+	Description         string                   `json:"description,omitempty"`
+	Email               string                   `json:"email,omitempty"`
+	Created             UnixTime                 `json:"created"`
+	Balance             *int                     `json:"account_balance,omitempty"`
+	Currency            string                   `json:"currency"`
+	Delinquent          bool                     `json:"delinquent,omitempty"`
+	Cards               *CardList                `json:"cards,omitempty"`
+	Sources             *PaymentSourceList       `json:"sources,omitempty"`
+	Discount            *Discount                `json:"discount,omitempty"`
+	Subscriptions       *SubscriptionList        `json:"subscriptions,omitempty"`
+	Livemode            bool                     `json:"livemode"`
+	DefaultSource       *PaymentSource           `json:"default_source,omitempty"`
+	Metadata            map[string]string        `json:"metadata,omitempty"`
+	TestClock           string                   `json:"test_clock,omitempty"`
+	Shipping            *CustomerShipping        `json:"shipping,omitempty"`
+	TaxExempt           string                   `json:"tax_exempt,omitempty"`
+	PreferredLocales    []string                 `json:"preferred_locales,omitempty"`
+	InvoiceSettings     *CustomerInvoiceSettings `json:"invoice_settings,omitempty"`
+	InvoicePrefix       string                   `json:"invoice_prefix,omitempty"`
+	NextInvoiceSequence int                      `json:"next_invoice_sequence,omitempty"`
+}
+
+// CustomerShipping holds where a customer's orders are shipped by default.
+// Unlike the ShippingDetails of a charge, it has no carrier or tracking
+// number.
+type CustomerShipping struct {
+	Name    string   `json:"name"`
+	Phone   string   `json:"phone,omitempty"`
+	Address *Address `json:"address"`
+}
+
+// CustomerInvoiceSettings holds the defaults applied to a customer's
+// invoices.
+type CustomerInvoiceSettings struct {
+	// The ID of the PaymentMethod invoices are paid with by default.
+	DefaultPaymentMethod string `json:"default_payment_method,omitempty"`
+
+	// Up to four name and value pairs shown on invoices, such as a purchase
+	// order number.
+	CustomFields []*InvoiceCustomField `json:"custom_fields,omitempty"`
+
+	// The footer shown on invoices.
+	Footer string `json:"footer,omitempty"`
+}
+
+// InvoiceCustomField is a name and value shown on an invoice.
+type InvoiceCustomField struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// UnmarshalJSON decodes a Customer, accepting the balance under either its
+// current name (account_balance) or its newer name (balance). A zero balance
+// is decoded as a pointer to 0, while a missing balance leaves Balance nil.
+// Likewise the default source is accepted as either default_source or
+// default_card.
+func (c *Customer) UnmarshalJSON(data []byte) error {
+	type customer Customer
+	aux := struct {
+		*customer
+		NewBalance  *int           `json:"balance"`
+		DefaultCard *PaymentSource `json:"default_card"`
+	}{customer: (*customer)(c)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	if c.Balance == nil {
+		c.Balance = aux.NewBalance
+	}
+	if c.DefaultSource == nil {
+		c.DefaultSource = aux.DefaultCard
+	}
+	return nil
+}
+
+// DefaultSourceID returns the ID of the customer's default source, whether or
+// not it was expanded, or an empty string if the customer has none.
+func (c *Customer) DefaultSourceID() string {
+	if c.DefaultSource == nil {
+		return ""
+	}
+	return c.DefaultSource.ID()
 }
 
 type SubscriptionList struct {
@@ -34,11 +107,35 @@ type SubscriptionList struct {
 	Data []*Subscription `json:"data"`
 }
 
+// Len returns the number of subscriptions in this page of the list.
+func (l *SubscriptionList) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the subscriptions in this page of the list, reporting a warning
+// if the list has more results.
+func (l *SubscriptionList) Page() []*Subscription {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
+
 type CardList struct {
 	ListObject
 	Data []*Card `json:"data"`
 }
 
+// Len returns the number of cards in this page of the list.
+func (l *CardList) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the cards in this page of the list, reporting a warning if the
+// list has more results.
+func (l *CardList) Page() []*Card {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
+
 // Discount represents the actual application of a coupon to a particular
 // customer.
 //
@@ -75,7 +172,7 @@ type CustomerParams struct {
 	Plan string
 
 	// (Optional) The quantity you’d like to apply to the subscription you’re creating.
-	Quantity int
+	Quantity *int
 
 	// (Optional) timestamp representing the end of the trial period
 	// the customer will get before being charged for the first time.
@@ -87,8 +184,35 @@ type CustomerParams struct {
 	// (Optional) Customer's default card id.
 	DefaultCard string
 
+	// (Optional) Where the customer's orders are shipped by default.
+	Shipping *CustomerShipping
+
+	// (Optional) Whether the customer is exempt from tax, such as
+	// TaxExemptReverse for a business liable for reverse charge. Invoices
+	// note the exemption.
+	TaxExempt string
+
+	// (Optional) The languages, such as "fr-CA", the customer prefers, in
+	// order. Invoices and receipts use the first one supported.
+	PreferredLocales []string
+
+	// (Optional) The defaults applied to the customer's invoices.
+	InvoiceSettings *CustomerInvoiceSettings
+
+	// (Optional) The prefix of the customer's invoice numbers, of 3 to 12
+	// uppercase letters or numbers, unique to the customer.
+	InvoicePrefix string
+
+	// (Optional) The sequence number of the customer's next invoice, which
+	// follows the prefix in its number.
+	NextInvoiceSequence *int
+
 	// (Optional) Metadata.
 	Metadata map[string]string
+
+	// (Optional) The ID of the TestClock to attach the customer to, so that
+	// its subscriptions follow the clock's time. Only used when creating.
+	TestClock string
 }
 
 // CustomerClient encapsulates operations for creating, updating, deleting and
@@ -101,7 +225,12 @@ type CustomerClient struct{}
 func (CustomerClient) Create(cust *CustomerParams) (*Customer, error) {
 	customer := Customer{}
 	params := make(url.Values)
-	appendCustomerParams(params, cust)
+	if err := appendCustomerParams(params, "", cust); err != nil {
+		return &customer, err
+	}
+	if cust.TestClock != "" {
+		params.Add("test_clock", cust.TestClock)
+	}
 
 	err := query("POST", "/customers", params, &customer)
 	return &customer, err
@@ -123,7 +252,9 @@ func (CustomerClient) Get(id string) (*Customer, error) {
 func (CustomerClient) Update(id string, cust *CustomerParams) (*Customer, error) {
 	customer := Customer{}
 	params := make(url.Values)
-	appendCustomerParams(params, cust)
+	if err := appendCustomerParams(params, id, cust); err != nil {
+		return &customer, err
+	}
 
 	err := query("POST", "/customers/"+url.QueryEscape(id), params, &customer)
 	return &customer, err
@@ -142,18 +273,47 @@ func (CustomerClient) Delete(id string) (bool, error) {
 //
 // see https://stripe.com/docs/api#list_customers
 func (CustomerClient) List(limit int, before, after string) ([]*Customer, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Customer
-	}{}
-	err := query("GET", "/customers", listParams(limit, before, after), &res)
-	return res.Data, res.More, err
+	var data []*Customer
+	list, err := queryList("/customers", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+// Returns a list of the PaymentMethods of the given type (e.g. card) saved to
+// the Customer with the given ID.
+//
+// see https://stripe.com/docs/api#customer_list_payment_methods
+func (CustomerClient) ListPaymentMethods(id, typ string, limit int, before, after string) ([]*PaymentMethod, bool, error) {
+	var data []*PaymentMethod
+	params := listParams(limit, before, after)
+	if typ != "" {
+		params.Add("type", typ)
+	}
+	path := "/customers/" + url.QueryEscape(id) + "/payment_methods"
+	list, err := queryList(path, params, &data)
+	return data, list.More, err
+}
+
+// Returns a list of the given customer's payment sources, of any type, or
+// only those of the given object type, such as SourceObjectBankAccount.
+//
+// see https://stripe.com/docs/api#list_sources
+func (CustomerClient) ListSources(id, object string, limit int, before, after string) ([]*PaymentSource, bool, error) {
+	var data []*PaymentSource
+	params := listParams(limit, before, after)
+	if object != "" {
+		params.Add("object", object)
+	}
+	path := "/customers/" + url.QueryEscape(id) + "/sources"
+	list, err := queryList(path, params, &data)
+	return data, list.More, err
 }
 
 ////////////////////////////////////////////////////////////////////////////////
 // Helper Function(s)
 
-func appendCustomerParams(values url.Values, c *CustomerParams) {
+// appendCustomerParams adds the params for creating a customer, or updating
+// the existing customer with the given ID, to values.
+func appendCustomerParams(values url.Values, id string, c *CustomerParams) error {
 	// add optional parameters, if specified
 	if c.Email != "" {
 		values.Add("email", c.Email)
@@ -167,7 +327,13 @@ func appendCustomerParams(values url.Values, c *CustomerParams) {
 	if c.Plan != "" {
 		values.Add("plan", c.Plan)
 	}
+	if c.Quantity != nil {
+		values.Add("quantity", strconv.Itoa(*c.Quantity))
+	}
 	if c.TrialEnd != nil {
+		if err := checkTrialEnd(c.TrialEnd, id, c.TestClock); err != nil {
+			return err
+		}
 		values.Add("trial_end", strconv.FormatInt(c.TrialEnd.Unix(), 10))
 	}
 	if c.Balance != nil {
@@ -176,51 +342,44 @@ func appendCustomerParams(values url.Values, c *CustomerParams) {
 	if c.DefaultCard != "" {
 		values.Add("default_card", c.DefaultCard)
 	}
-	appendMetadata(values, c.Metadata)
-
-	// add optional credit card details, if specified
-	if c.Card != nil {
-		appendCardParams(values, true, c.Card)
-	} else if c.Token != "" {
-		values.Add("card", c.Token)
+	if c.TaxExempt != "" {
+		values.Add("tax_exempt", c.TaxExempt)
 	}
-}
-
-func appendCardParams(values url.Values, nested bool, c *CardParams) {
-	p := func(s string) string {
-		if nested {
-			return "card[" + s + "]"
-		}
-		return s
-	}
-	if c.Number != "" {
-		values.Add(p("number"), c.Number)
-	}
-	if c.ExpMonth != 0 {
-		values.Add(p("exp_month"), strconv.Itoa(c.ExpMonth))
-	}
-	if c.ExpMonth != 0 {
-		values.Add(p("exp_year"), strconv.Itoa(c.ExpYear))
+	for _, locale := range c.PreferredLocales {
+		values.Add("preferred_locales[]", locale)
 	}
-	if c.Name != "" {
-		values.Add(p("name"), c.Name)
-	}
-	if c.CVC != "" {
-		values.Add(p("cvc"), c.CVC)
-	}
-	if c.Address1 != "" {
-		values.Add(p("address_line1"), c.Address1)
+	if is := c.InvoiceSettings; is != nil {
+		if is.DefaultPaymentMethod != "" {
+			values.Add("invoice_settings[default_payment_method]", is.DefaultPaymentMethod)
+		}
+		for i, f := range is.CustomFields {
+			p := "invoice_settings[custom_fields][" + strconv.Itoa(i) + "]"
+			values.Add(p+"[name]", f.Name)
+			values.Add(p+"[value]", f.Value)
+		}
+		if is.Footer != "" {
+			values.Add("invoice_settings[footer]", is.Footer)
+		}
 	}
-	if c.Address2 != "" {
-		values.Add(p("address_line2"), c.Address2)
+	if c.InvoicePrefix != "" {
+		values.Add("invoice_prefix", c.InvoicePrefix)
 	}
-	if c.AddressZip != "" {
-		values.Add(p("address_zip"), c.AddressZip)
+	if c.NextInvoiceSequence != nil {
+		values.Add("next_invoice_sequence", strconv.Itoa(*c.NextInvoiceSequence))
 	}
-	if c.AddressState != "" {
-		values.Add(p("address_state"), c.AddressState)
+	if s := c.Shipping; s != nil {
+		appendShippingDetails(values, "shipping", &ShippingDetails{Name: s.Name, Phone: s.Phone, Address: s.Address})
 	}
-	if c.AddressCountry != "" {
-		values.Add(p("address_country"), c.AddressCountry)
+	appendMetadata(values, c.Metadata)
+
+	// add optional credit card details, if specified
+	if c.Card != nil {
+		if err := c.Card.Validate(); err != nil {
+			return err
+		}
+		appendCardParams(values, c.Card)
+	} else if c.Token != "" {
+		values.Add("card", c.Token)
 	}
+	return nil
 }
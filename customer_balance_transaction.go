@@ -0,0 +1,101 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// CustomerBalanceTransaction represents a credit or debit to a customer's
+// balance, which is applied to the customer's next invoices.
+//
+// see https://stripe.com/docs/api#customer_balance_transaction_object
+type CustomerBalanceTransaction struct {
+	ID            string            `json:"id"`
+	Amount        int               `json:"amount"`
+	Currency      string            `json:"currency"`
+	Customer      string            `json:"customer"`
+	Description   string            `json:"description,omitempty"`
+	EndingBalance int               `json:"ending_balance"`
+	Invoice       string            `json:"invoice,omitempty"`
+	Type          string            `json:"type"`
+	Created       UnixTime          `json:"created"`
+	Livemode      bool              `json:"livemode"`
+	Metadata      map[string]string `json:"metadata,omitempty"`
+}
+
+// CustomerBalanceTransactionParams encapsulates options for creating and
+// updating Customer Balance Transactions.
+type CustomerBalanceTransactionParams struct {
+	// The integer amount in cents to apply to the customer's balance. Pass a
+	// negative amount to credit the customer, and a positive amount to debit.
+	// Only used, and required, when creating.
+	Amount int
+
+	// 3-letter ISO code for currency. Only used, and required, when creating.
+	Currency string
+
+	// (Optional) An arbitrary string describing the transaction.
+	Description string
+
+	Metadata map[string]string
+}
+
+// CustomerBalanceTransactionClient encapsulates operations for creating,
+// updating and querying the balance transactions of a customer using the
+// Stripe REST API.
+type CustomerBalanceTransactionClient struct{}
+
+func (c CustomerBalanceTransactionClient) path(customerID, transactionID string) string {
+	p := fmt.Sprintf("/customers/%s/balance_transactions", url.QueryEscape(customerID))
+	if transactionID != "" {
+		p += "/" + url.QueryEscape(transactionID)
+	}
+	return p
+}
+
+// Credits or debits the given customer's balance.
+//
+// see https://stripe.com/docs/api#create_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Create(customerID string, params *CustomerBalanceTransactionParams) (*CustomerBalanceTransaction, error) {
+	values := c.values(params)
+	values.Add("amount", strconv.Itoa(params.Amount))
+	values.Add("currency", params.Currency)
+	res := &CustomerBalanceTransaction{}
+	return res, query("POST", c.path(customerID, ""), values, res)
+}
+
+// Retrieves the Customer Balance Transaction with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Get(customerID, transactionID string) (*CustomerBalanceTransaction, error) {
+	res := &CustomerBalanceTransaction{}
+	return res, query("GET", c.path(customerID, transactionID), nil, res)
+}
+
+// Updates the description or metadata of the Customer Balance Transaction
+// with the given ID. The amount cannot be changed.
+//
+// see https://stripe.com/docs/api#update_customer_balance_transaction
+func (c CustomerBalanceTransactionClient) Update(customerID, transactionID string, params *CustomerBalanceTransactionParams) (*CustomerBalanceTransaction, error) {
+	res := &CustomerBalanceTransaction{}
+	return res, query("POST", c.path(customerID, transactionID), c.values(params), res)
+}
+
+// Returns a list of the given customer's balance transactions.
+//
+// see https://stripe.com/docs/api#list_customer_balance_transactions
+func (c CustomerBalanceTransactionClient) List(customerID string, limit int, before, after string) ([]*CustomerBalanceTransaction, bool, error) {
+	var data []*CustomerBalanceTransaction
+	list, err := queryList(c.path(customerID, ""), listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (c CustomerBalanceTransactionClient) values(params *CustomerBalanceTransactionParams) url.Values {
+	values := make(url.Values)
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
@@ -0,0 +1,42 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateCustomerBalanceTransaction will test that a credit is applied
+// to a customer's balance with its description and metadata.
+func TestCreateCustomerBalanceTransaction(t *testing.T) {
+	req, done := mockServer(`{"id": "cbtxn_1", "amount": -500, "currency": "usd", "customer": "cus_1", "ending_balance": -500, "type": "adjustment"}`)
+	defer done()
+
+	txn, err := CustomerBalanceTransactions.Create("cus_1", &CustomerBalanceTransactionParams{
+		Amount:      -500,
+		Currency:    USD,
+		Description: "Goodwill credit",
+		Metadata:    map[string]string{"ticket": "1234"},
+	})
+	if err != nil {
+		t.Errorf("Expected Customer Balance Transaction, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/customers/cus_1/balance_transactions" {
+		t.Errorf("Expected POST /v1/customers/cus_1/balance_transactions, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"amount":           "-500",
+		"currency":         "usd",
+		"description":      "Goodwill credit",
+		"metadata[ticket]": "1234",
+	})
+	if txn.EndingBalance != -500 {
+		t.Errorf("Expected Ending Balance -500, got %d", txn.EndingBalance)
+	}
+
+	if _, err := CustomerBalanceTransactions.Update("cus_1", "cbtxn_1", &CustomerBalanceTransactionParams{Description: "Credit"}); err != nil {
+		t.Errorf("Expected Customer Balance Transaction, got Error %s", err.Error())
+	}
+	if _, ok := req.Form["amount"]; ok || req.Path != "/v1/customers/cus_1/balance_transactions/cbtxn_1" {
+		t.Errorf("Expected update of cbtxn_1 without amount, got %s %v", req.Path, req.Form)
+	}
+}
@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"encoding/json"
 	"testing"
 	"time"
 )
@@ -167,7 +168,7 @@ func TestUpdateCustomer(t *testing.T) {
 	if cust.Email != "joe@email.com" {
 		t.Errorf("Expected Updated Customer Email")
 	}
-	if cust.Balance != balance {
+	if cust.Balance == nil || *cust.Balance != balance {
 		t.Errorf("Expected Updated Customer Balance")
 	}
 }
@@ -212,3 +213,286 @@ func TestListCustomers(t *testing.T) {
 		t.Errorf("Expected 2 Customers, got %d", len(customers))
 	}
 }
+
+// TestDecodeCustomerBalance will test that the customer balance is decoded
+// from either field name, and that a zero balance is distinct from no balance.
+func TestDecodeCustomerBalance(t *testing.T) {
+	tests := []struct {
+		JSON    string
+		Balance *int
+	}{
+		{`{"id":"cus_1"}`, nil},
+		{`{"id":"cus_1","account_balance":0}`, new(int)},
+		{`{"id":"cus_1","account_balance":-100}`, Int(-100)},
+		{`{"id":"cus_1","balance":250}`, Int(250)},
+		{`{"id":"cus_1","balance":0}`, new(int)},
+	}
+
+	for _, test := range tests {
+		cust := Customer{}
+		if err := json.Unmarshal([]byte(test.JSON), &cust); err != nil {
+			t.Errorf("Expected Customer decoded, got Error %s", err.Error())
+			continue
+		}
+		if cust.ID != "cus_1" {
+			t.Errorf("Expected Customer ID cus_1, got %s", cust.ID)
+		}
+		switch {
+		case test.Balance == nil && cust.Balance != nil:
+			t.Errorf("Expected no Customer Balance for %s, got %d", test.JSON, *cust.Balance)
+		case test.Balance != nil && cust.Balance == nil:
+			t.Errorf("Expected Customer Balance %d for %s, got nil", *test.Balance, test.JSON)
+		case test.Balance != nil && *cust.Balance != *test.Balance:
+			t.Errorf("Expected Customer Balance %d for %s, got %d", *test.Balance, test.JSON, *cust.Balance)
+		}
+	}
+}
+
+// TestListCustomerPaymentMethods will test that a customer's saved card
+// PaymentMethods are listed from the customer payment methods endpoint.
+func TestListCustomerPaymentMethods(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "pm_1", "type": "card", "card": {"brand": "visa", "last4": "4242"}}]}`)
+	defer done()
+
+	methods, more, err := Customers.ListPaymentMethods("cus_1", PaymentMethodCard, 10, "", "")
+	if err != nil {
+		t.Errorf("Expected PaymentMethod List, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "GET" || req.Path != "/v1/customers/cus_1/payment_methods" {
+		t.Errorf("Expected GET /v1/customers/cus_1/payment_methods, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("type"); got != PaymentMethodCard {
+		t.Errorf("Expected type %s, got %q", PaymentMethodCard, got)
+	}
+	if len(methods) != 1 || methods[0].Card == nil || methods[0].Card.Last4 != "4242" || more {
+		t.Errorf("Expected a single card PaymentMethod, got %v", methods)
+	}
+}
+
+// TestTruncatedCardList will test that reading a page of a customer's cards
+// reports a warning only when the list has more results.
+func TestTruncatedCardList(t *testing.T) {
+	var warned []string
+	SetTruncatedListHandler(func(l *ListObject) { warned = append(warned, l.URL) })
+	defer SetTruncatedListHandler(nil)
+
+	cust := Customer{}
+	json.Unmarshal([]byte(`{"id": "cus_1", "cards": {"object": "list", "url": "/v1/customers/cus_1/cards", "has_more": true, "data": [{"id": "card_1"}]}}`), &cust)
+
+	if !cust.Cards.HasMore() || cust.Cards.Len() != 1 {
+		t.Errorf("Expected 1 Card with more results, got %d (more: %v)", cust.Cards.Len(), cust.Cards.HasMore())
+	}
+	if len(warned) != 0 {
+		t.Errorf("Expected no warning before reading the page, got %v", warned)
+	}
+	if cards := cust.Cards.Page(); len(cards) != 1 {
+		t.Errorf("Expected 1 Card, got %d", len(cards))
+	}
+	if len(warned) != 1 || warned[0] != "/v1/customers/cus_1/cards" {
+		t.Errorf("Expected a warning for /v1/customers/cus_1/cards, got %v", warned)
+	}
+
+	cust.Cards.More = false
+	cust.Cards.Page()
+	if len(warned) != 1 {
+		t.Errorf("Expected no warning for a complete list, got %v", warned)
+	}
+}
+
+// TestCustomerShipping will test that a customer's shipping address is sent
+// and decoded on the customer.
+func TestCustomerShipping(t *testing.T) {
+	req, done := mockServer(`{"id": "cus_1", "shipping": {"name": "Jenny Rosen", "phone": "555-1234", "address": {"line1": "1 Main St", "city": "Springfield"}}}`)
+	defer done()
+
+	cust, err := Customers.Update("cus_1", &CustomerParams{
+		Shipping: &CustomerShipping{
+			Name:    "Jenny Rosen",
+			Phone:   "555-1234",
+			Address: &Address{Line1: "1 Main St", City: "Springfield"},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"shipping[name]":           "Jenny Rosen",
+		"shipping[phone]":          "555-1234",
+		"shipping[address][line1]": "1 Main St",
+		"shipping[address][city]":  "Springfield",
+	})
+	if cust.Shipping == nil || cust.Shipping.Address == nil || cust.Shipping.Address.City != "Springfield" {
+		t.Errorf("Expected Customer shipping to Springfield, got %+v", cust.Shipping)
+	}
+}
+
+// TestCustomerTaxExemptAndLocales will test that a customer's tax exemption
+// and preferred locales are sent and decoded.
+func TestCustomerTaxExemptAndLocales(t *testing.T) {
+	req, done := mockServer(`{"id": "cus_1", "tax_exempt": "reverse", "preferred_locales": ["fr-CA", "en"]}`)
+	defer done()
+
+	cust, err := Customers.Update("cus_1", &CustomerParams{
+		TaxExempt:        TaxExemptReverse,
+		PreferredLocales: []string{"fr-CA", "en"},
+	})
+	if err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("tax_exempt"); got != "reverse" {
+		t.Errorf("Expected param tax_exempt=reverse, got %q", got)
+	}
+	if got := req.Form["preferred_locales[]"]; len(got) != 2 || got[0] != "fr-CA" || got[1] != "en" {
+		t.Errorf("Expected preferred locales fr-CA, en, got %v", got)
+	}
+	if cust.TaxExempt != TaxExemptReverse || len(cust.PreferredLocales) != 2 {
+		t.Errorf("Expected reverse charge Customer with 2 locales, got %+v", cust)
+	}
+}
+
+// TestCustomerInvoiceSettings will test that a customer's invoice defaults
+// and numbering are sent and decoded.
+func TestCustomerInvoiceSettings(t *testing.T) {
+	req, done := mockServer(`{"id": "cus_1", "invoice_prefix": "ACME", "next_invoice_sequence": 42, "invoice_settings": {"footer": "Thanks!", "custom_fields": [{"name": "PO", "value": "123"}]}}`)
+	defer done()
+
+	cust, err := Customers.Update("cus_1", &CustomerParams{
+		InvoiceSettings: &CustomerInvoiceSettings{
+			DefaultPaymentMethod: "pm_1",
+			CustomFields:         []*InvoiceCustomField{{Name: "PO", Value: "123"}},
+			Footer:               "Thanks!",
+		},
+		InvoicePrefix:       "ACME",
+		NextInvoiceSequence: Int(42),
+	})
+	if err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"invoice_settings[default_payment_method]":  "pm_1",
+		"invoice_settings[custom_fields][0][name]":  "PO",
+		"invoice_settings[custom_fields][0][value]": "123",
+		"invoice_settings[footer]":                  "Thanks!",
+		"invoice_prefix":                            "ACME",
+		"next_invoice_sequence":                     "42",
+	})
+	if cust.InvoicePrefix != "ACME" || cust.NextInvoiceSequence != 42 {
+		t.Errorf("Expected invoice numbering ACME-42, got %q-%d", cust.InvoicePrefix, cust.NextInvoiceSequence)
+	}
+	if is := cust.InvoiceSettings; is == nil || is.Footer != "Thanks!" || len(is.CustomFields) != 1 {
+		t.Errorf("Expected invoice settings with footer and custom field, got %+v", is)
+	}
+}
+
+// TestListCustomerSources will test that a customer's sources of every type
+// are decoded from one list.
+func TestListCustomerSources(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [
+		{"object": "card", "id": "card_1", "last4": "4242"},
+		{"object": "bank_account", "id": "ba_1", "bank_name": "STRIPE TEST BANK"},
+		{"object": "source", "id": "src_1", "type": "alipay", "alipay": {}}
+	]}`)
+	defer done()
+
+	sources, _, err := Customers.ListSources("cus_1", "", 10, "", "")
+	if err != nil {
+		t.Errorf("Expected Sources, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "GET" || req.Path != "/v1/customers/cus_1/sources" {
+		t.Errorf("Expected GET /v1/customers/cus_1/sources, got %s %s", req.Method, req.Path)
+	}
+	if _, ok := req.Form["object"]; ok {
+		t.Errorf("Expected no object filter, got %q", req.Form.Get("object"))
+	}
+	if len(sources) != 3 {
+		t.Errorf("Expected 3 Sources, got %d", len(sources))
+		return
+	}
+	if sources[0].Card == nil || sources[0].Card.Last4 != "4242" {
+		t.Errorf("Expected card ending 4242, got %+v", sources[0])
+	}
+	if sources[1].BankAccount == nil || sources[1].BankAccount.BankName != "STRIPE TEST BANK" {
+		t.Errorf("Expected bank account, got %+v", sources[1])
+	}
+	if sources[2].Source == nil || sources[2].Source.Type != SourceTypeAlipay {
+		t.Errorf("Expected alipay Source, got %+v", sources[2])
+	}
+	for i, id := range []string{"card_1", "ba_1", "src_1"} {
+		if sources[i].ID() != id {
+			t.Errorf("Expected Source %s, got %s", id, sources[i].ID())
+		}
+	}
+
+	Customers.ListSources("cus_1", SourceObjectBankAccount, 10, "", "")
+	if got := req.Form.Get("object"); got != "bank_account" {
+		t.Errorf("Expected param object=bank_account, got %q", got)
+	}
+}
+
+// TestDecodeCustomerSources will test that the sources embedded in a
+// customer keep their types, and that unknown source objects are skipped
+// rather than failing to decode.
+func TestDecodeCustomerSources(t *testing.T) {
+	cust := &Customer{}
+	err := json.Unmarshal([]byte(`{"id": "cus_1", "sources": {"object": "list", "data": [
+		{"object": "bank_account", "id": "ba_1"},
+		{"object": "bitcoin_receiver", "id": "btcrcv_1"}
+	]}}`), cust)
+	if err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+		return
+	}
+	if cust.Sources == nil || cust.Sources.Len() != 2 {
+		t.Errorf("Expected 2 Sources, got %+v", cust.Sources)
+		return
+	}
+	if s := cust.Sources.Data[0]; s.BankAccount == nil || s.ID() != "ba_1" {
+		t.Errorf("Expected bank account ba_1, got %+v", s)
+	}
+	if s := cust.Sources.Data[1]; s.Object != "bitcoin_receiver" || s.ID() != "" {
+		t.Errorf("Expected unknown bitcoin_receiver source, got %+v", s)
+	}
+}
+
+// TestDecodeCustomerDefaultSource will test that a Customer's default source
+// is decoded from either default_source or default_card, whether it is an ID
+// or an expanded object.
+func TestDecodeCustomerDefaultSource(t *testing.T) {
+	tests := []struct {
+		JSON     string
+		ID       string
+		Expanded bool
+	}{
+		{`{"id":"cus_1"}`, "", false},
+		{`{"id":"cus_1","default_source":null}`, "", false},
+		{`{"id":"cus_1","default_source":"card_1"}`, "card_1", false},
+		{`{"id":"cus_1","default_card":"card_1"}`, "card_1", false},
+		{`{"id":"cus_1","default_source":{"object":"card","id":"card_1"}}`, "card_1", true},
+		{`{"id":"cus_1","default_source":"card_2","default_card":"card_1"}`, "card_2", false},
+	}
+
+	for _, test := range tests {
+		cust := Customer{}
+		if err := json.Unmarshal([]byte(test.JSON), &cust); err != nil {
+			t.Errorf("Expected Customer decoded, got Error %s", err.Error())
+			continue
+		}
+		if id := cust.DefaultSourceID(); id != test.ID {
+			t.Errorf("Expected default source %q for %s, got %q", test.ID, test.JSON, id)
+		}
+		if cust.DefaultSource != nil && cust.DefaultSource.Expanded() != test.Expanded {
+			t.Errorf("Expected default source Expanded %v for %s, got %v", test.Expanded, test.JSON, !test.Expanded)
+		}
+	}
+
+	cust := Customer{}
+	json.Unmarshal([]byte(`{"id":"cus_1","default_source":{"object":"card","id":"card_1","last4":"4242"}}`), &cust)
+	if cust.DefaultSource.Card == nil || cust.DefaultSource.Card.Last4 != "4242" {
+		t.Errorf("Expected expanded default Card, got %+v", cust.DefaultSource)
+	}
+}
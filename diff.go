@@ -0,0 +1,136 @@
+package stripe
+
+// DiffMetadata returns the metadata to send in an update so that current
+// becomes desired: new and changed keys with their desired values, and keys
+// missing from desired with an empty value, which Stripe treats as a
+// deletion. Keys that are unchanged are left out, so concurrent edits to them
+// are not overwritten. It returns nil if nothing changed.
+func DiffMetadata(current, desired map[string]string) map[string]string {
+	var diff map[string]string
+	set := func(k, v string) {
+		if diff == nil {
+			diff = make(map[string]string)
+		}
+		diff[k] = v
+	}
+	for k, v := range desired {
+		if cur, ok := current[k]; !ok || cur != v {
+			set(k, v)
+		}
+	}
+	for k := range current {
+		if _, ok := desired[k]; !ok {
+			set(k, "")
+		}
+	}
+	return diff
+}
+
+// DiffCustomer returns the params to update the fetched Customer to the
+// desired state, containing only the fields that differ, and whether there
+// is anything to update. Empty and nil fields in desired are left unchanged,
+// except for Metadata, which is compared with DiffMetadata when not nil.
+// Fields with no counterpart on Customer, such as Card, Plan or Quantity, are
+// copied when set.
+func DiffCustomer(current *Customer, desired *CustomerParams) (*CustomerParams, bool) {
+	diff := &CustomerParams{
+		Card:     desired.Card,
+		Token:    desired.Token,
+		Plan:     desired.Plan,
+		Quantity: desired.Quantity,
+		TrialEnd: desired.TrialEnd,
+	}
+	changed := diff.Card != nil || diff.Token != "" || diff.Plan != "" || diff.Quantity != nil || diff.TrialEnd != nil
+
+	if desired.Email != "" && desired.Email != current.Email {
+		diff.Email, changed = desired.Email, true
+	}
+	if desired.Description != "" && desired.Description != current.Description {
+		diff.Description, changed = desired.Description, true
+	}
+	if desired.Coupon != "" && (current.Discount == nil || current.Discount.Coupon == nil || current.Discount.Coupon.ID != desired.Coupon) {
+		diff.Coupon, changed = desired.Coupon, true
+	}
+	if desired.Balance != nil && (current.Balance == nil || *current.Balance != *desired.Balance) {
+		diff.Balance, changed = desired.Balance, true
+	}
+	if desired.DefaultCard != "" && desired.DefaultCard != current.DefaultSourceID() {
+		diff.DefaultCard, changed = desired.DefaultCard, true
+	}
+	if desired.Metadata != nil {
+		if diff.Metadata = DiffMetadata(current.Metadata, desired.Metadata); diff.Metadata != nil {
+			changed = true
+		}
+	}
+	return diff, changed
+}
+
+// DiffPlan returns the params to update the fetched Plan to the desired
+// state, and whether there is anything to update. Only the fields that can be
+// updated on a Plan are compared: the name, statement description and
+// metadata.
+func DiffPlan(current *Plan, desired *PlanParams) (*PlanParams, bool) {
+	diff := &PlanParams{}
+	changed := false
+	if desired.Name != "" && desired.Name != current.Name {
+		diff.Name, changed = desired.Name, true
+	}
+	if desired.StatementDescription != nil && *desired.StatementDescription != current.StatementDescription {
+		diff.StatementDescription, changed = desired.StatementDescription, true
+	}
+	if desired.Metadata != nil {
+		if diff.Metadata = DiffMetadata(current.Metadata, desired.Metadata); diff.Metadata != nil {
+			changed = true
+		}
+	}
+	return diff, changed
+}
+
+// DiffSubscription returns the params to update the fetched Subscription to
+// the desired state, containing only the fields that differ, and whether
+// there is anything to update. Prorate is copied as it only affects how a
+// change is billed, and a new Card or Token is copied when set.
+func DiffSubscription(current *Subscription, desired *SubscriptionParams) (*SubscriptionParams, bool) {
+	diff := &SubscriptionParams{
+		Prorate: desired.Prorate,
+		Card:    desired.Card,
+		Token:   desired.Token,
+	}
+	changed := diff.Card != nil || diff.Token != ""
+
+	if desired.Plan != "" && (current.Plan == nil || current.Plan.ID != desired.Plan) {
+		diff.Plan, changed = desired.Plan, true
+	}
+	if desired.Coupon != "" && (current.Discount == nil || current.Discount.Coupon == nil || current.Discount.Coupon.ID != desired.Coupon) {
+		diff.Coupon, changed = desired.Coupon, true
+	}
+	if desired.Quantity != nil && *desired.Quantity != current.Quantity {
+		diff.Quantity, changed = desired.Quantity, true
+	}
+	if desired.TrialEnd != nil && (current.TrialEnd == nil || current.TrialEnd.Unix() != desired.TrialEnd.Unix()) {
+		diff.TrialEnd, changed = desired.TrialEnd, true
+	}
+	if desired.DefaultTaxRates != nil && !sameTaxRates(current.DefaultTaxRates, desired.DefaultTaxRates) {
+		diff.DefaultTaxRates, changed = desired.DefaultTaxRates, true
+	}
+	return diff, changed
+}
+
+// sameTaxRates returns true if the tax rates have exactly the given IDs, in
+// any order.
+func sameTaxRates(rates []*TaxRate, ids []string) bool {
+	if len(rates) != len(ids) {
+		return false
+	}
+	seen := make(map[string]int)
+	for _, rate := range rates {
+		seen[rate.ID]++
+	}
+	for _, id := range ids {
+		if seen[id] == 0 {
+			return false
+		}
+		seen[id]--
+	}
+	return true
+}
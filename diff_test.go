@@ -0,0 +1,75 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestDiffMetadata will test that only changed, new and removed keys are
+// included in a metadata diff.
+func TestDiffMetadata(t *testing.T) {
+	diff := DiffMetadata(
+		map[string]string{"a": "1", "b": "2", "c": "3"},
+		map[string]string{"a": "1", "b": "20", "d": "4"},
+	)
+	expected := map[string]string{"b": "20", "c": "", "d": "4"}
+	if len(diff) != len(expected) {
+		t.Errorf("Expected Metadata diff %v, got %v", expected, diff)
+	}
+	for k, v := range expected {
+		if got, ok := diff[k]; !ok || got != v {
+			t.Errorf("Expected Metadata diff %s=%q, got %q", k, v, got)
+		}
+	}
+
+	if diff := DiffMetadata(map[string]string{"a": "1"}, map[string]string{"a": "1"}); diff != nil {
+		t.Errorf("Expected no Metadata diff, got %v", diff)
+	}
+}
+
+// TestDiffCustomer will test that only changed Customer fields are included
+// in the update params.
+func TestDiffCustomer(t *testing.T) {
+	cust := &Customer{Email: "jerry@example.com", Description: "Jerry", Balance: Int(0), Metadata: map[string]string{"tier": "gold"}}
+
+	if _, changed := DiffCustomer(cust, &CustomerParams{Email: "jerry@example.com", Balance: Int(0), Metadata: map[string]string{"tier": "gold"}}); changed {
+		t.Errorf("Expected no Customer changes")
+	}
+
+	diff, changed := DiffCustomer(cust, &CustomerParams{Email: "jerry@example.com", Description: "Jerry Seinfeld", Balance: Int(-500)})
+	if !changed {
+		t.Errorf("Expected Customer changes")
+	}
+	if diff.Email != "" || diff.Description != "Jerry Seinfeld" || diff.Balance == nil || *diff.Balance != -500 || diff.Metadata != nil {
+		t.Errorf("Expected only Description and Balance in Customer diff, got %+v", diff)
+	}
+}
+
+// TestDiffSubscription will test that the quantity and plan are only
+// included in the update params when they changed.
+func TestDiffSubscription(t *testing.T) {
+	sub := &Subscription{Plan: &Plan{ID: "plan1"}, Quantity: 5, DefaultTaxRates: []*TaxRate{{ID: "txr_1"}}}
+
+	if _, changed := DiffSubscription(sub, &SubscriptionParams{Plan: "plan1", Quantity: Int(5), DefaultTaxRates: []string{"txr_1"}}); changed {
+		t.Errorf("Expected no Subscription changes")
+	}
+
+	diff, changed := DiffSubscription(sub, &SubscriptionParams{Plan: "plan1", Quantity: Int(7), Prorate: Bool(false)})
+	if !changed {
+		t.Errorf("Expected Subscription changes")
+	}
+	if diff.Plan != "" || diff.Quantity == nil || *diff.Quantity != 7 || diff.Prorate == nil {
+		t.Errorf("Expected only Quantity and Prorate in Subscription diff, got %+v", diff)
+	}
+}
+
+// TestDiffPlan will test that only a Plan's updatable fields are diffed.
+func TestDiffPlan(t *testing.T) {
+	plan := &Plan{Name: "Gold", Metadata: map[string]string{"seats": "5"}}
+	diff, changed := DiffPlan(plan, &PlanParams{Name: "Gold", Amount: 2000, Metadata: map[string]string{"seats": "10"}})
+	if !changed {
+		t.Errorf("Expected Plan changes")
+	}
+	if diff.Name != "" || diff.Amount != 0 || diff.Metadata["seats"] != "10" {
+		t.Errorf("Expected only Metadata in Plan diff, got %+v", diff)
+	}
+}
@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Early Fraud Warning Types
+const (
+	FraudCardNeverReceived     = "card_never_received"
+	FraudCardApplication       = "fraudulent_card_application"
+	FraudCounterfeitCard       = "made_with_counterfeit_card"
+	FraudLostCard              = "made_with_lost_card"
+	FraudStolenCard            = "made_with_stolen_card"
+	FraudMisc                  = "misc"
+	FraudUnauthorizedUseOfCard = "unauthorized_use_of_card"
+)
+
+// EarlyFraudWarning represents a report from the card issuer that a charge
+// is likely to be fraudulent, usually received before the charge is
+// disputed. Refunding an actionable charge avoids the dispute.
+//
+// see https://stripe.com/docs/api#early_fraud_warning_object
+type EarlyFraudWarning struct {
+	ID         string   `json:"id"`
+	Charge     string   `json:"charge"`
+	FraudType  string   `json:"fraud_type"`
+	Actionable bool     `json:"actionable"`
+	Created    UnixTime `json:"created"`
+	Livemode   bool     `json:"livemode"`
+}
+
+// EarlyFraudWarningClient encapsulates operations for querying Radar early
+// fraud warnings using the Stripe REST API.
+type EarlyFraudWarningClient struct{}
+
+// Retrieves the Early Fraud Warning with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_early_fraud_warning
+func (EarlyFraudWarningClient) Get(id string) (*EarlyFraudWarning, error) {
+	res := &EarlyFraudWarning{}
+	return res, query("GET", "/radar/early_fraud_warnings/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of Early Fraud Warnings, optionally only those for the
+// charge with the given ID.
+//
+// see https://stripe.com/docs/api#list_early_fraud_warnings
+func (EarlyFraudWarningClient) List(chargeID string, limit int, before, after string) ([]*EarlyFraudWarning, bool, error) {
+	var data []*EarlyFraudWarning
+	params := listParams(limit, before, after)
+	if chargeID != "" {
+		params.Add("charge", chargeID)
+	}
+	list, err := queryList("/radar/early_fraud_warnings", params, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,31 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestListEarlyFraudWarnings will test that Early Fraud Warnings are listed
+// for a charge, and decoded with their fraud type.
+func TestListEarlyFraudWarnings(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "issfr_1", "charge": "ch_1", "fraud_type": "made_with_stolen_card", "actionable": true}]}`)
+	defer done()
+
+	warnings, _, err := EarlyFraudWarnings.List("ch_1", 10, "", "")
+	if err != nil {
+		t.Errorf("Expected Early Fraud Warnings, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "GET" || req.Path != "/v1/radar/early_fraud_warnings" {
+		t.Errorf("Expected GET /v1/radar/early_fraud_warnings, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("charge"); got != "ch_1" {
+		t.Errorf("Expected param charge=ch_1, got %q", got)
+	}
+	if len(warnings) != 1 {
+		t.Errorf("Expected 1 Early Fraud Warning, got %d", len(warnings))
+		return
+	}
+	if w := warnings[0]; !w.Actionable || w.FraudType != FraudStolenCard || w.Charge != "ch_1" {
+		t.Errorf("Expected actionable warning of stolen card for ch_1, got %+v", w)
+	}
+}
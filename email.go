@@ -0,0 +1,143 @@
+package stripe
+
+import (
+	"sort"
+	"strings"
+	"sync"
+)
+
+// EmailNormalizer normalizes email addresses, so that the same address is
+// stored and looked up the same way however it was typed.
+type EmailNormalizer struct {
+	// Whether to remove plus-address tags, so that jerry+billing@example.com
+	// is normalized to jerry@example.com.
+	StripPlusTags bool
+}
+
+// Normalize returns the email address trimmed, lowercased and, if the policy
+// says so, without its plus-address tag. Use it when setting an email on a
+// customer, and when looking one up in an EmailIndex.
+func (n EmailNormalizer) Normalize(email string) string {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if !n.StripPlusTags {
+		return email
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return email
+	}
+	if plus := strings.Index(email[:at], "+"); plus >= 0 {
+		email = email[:plus] + email[at:]
+	}
+	return email
+}
+
+// EmailIndex is a local index of customer IDs by normalized email address,
+// since customers cannot be searched by email. Build it with Backfill, and
+// keep it up to date by passing customer events to Apply. It is safe for
+// concurrent use.
+type EmailIndex struct {
+	normalizer EmailNormalizer
+
+	mu     sync.RWMutex
+	ids    map[string]map[string]bool // email -> customer IDs
+	emails map[string]string          // customer ID -> email
+}
+
+// NewEmailIndex returns an empty index that normalizes emails with the given
+// normalizer.
+func NewEmailIndex(normalizer EmailNormalizer) *EmailIndex {
+	return &EmailIndex{
+		normalizer: normalizer,
+		ids:        make(map[string]map[string]bool),
+		emails:     make(map[string]string),
+	}
+}
+
+// Add indexes the customer by its email, replacing any previous entry for
+// the customer. Customers without an email are removed from the index.
+func (x *EmailIndex) Add(c *Customer) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(c.ID)
+	email := x.normalizer.Normalize(c.Email)
+	if email == "" {
+		return
+	}
+	if x.ids[email] == nil {
+		x.ids[email] = make(map[string]bool)
+	}
+	x.ids[email][c.ID] = true
+	x.emails[c.ID] = email
+}
+
+// Remove removes the customer with the given ID from the index.
+func (x *EmailIndex) Remove(customerID string) {
+	x.mu.Lock()
+	defer x.mu.Unlock()
+	x.remove(customerID)
+}
+
+func (x *EmailIndex) remove(customerID string) {
+	email, ok := x.emails[customerID]
+	if !ok {
+		return
+	}
+	delete(x.emails, customerID)
+	delete(x.ids[email], customerID)
+	if len(x.ids[email]) == 0 {
+		delete(x.ids, email)
+	}
+}
+
+// Lookup returns the IDs of the customers with the given email, after
+// normalizing it, in sorted order. Several customers may share an email.
+func (x *EmailIndex) Lookup(email string) []string {
+	x.mu.RLock()
+	defer x.mu.RUnlock()
+	var ids []string
+	for id := range x.ids[x.normalizer.Normalize(email)] {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// Backfill lists every customer, in pages of the given size, or of the size
+// set with SetPageSize if 0, and adds them to the index.
+func (x *EmailIndex) Backfill(size int) error {
+	after := ""
+	for {
+		customers, more, err := Customers.List(pageSize(size), "", after)
+		if err != nil {
+			return err
+		}
+		for _, c := range customers {
+			x.Add(c)
+		}
+		if !more || len(customers) == 0 {
+			return nil
+		}
+		after = customers[len(customers)-1].ID
+	}
+}
+
+// Apply updates the index from a customer.created, customer.updated or
+// customer.deleted event. Other events are ignored.
+func (x *EmailIndex) Apply(e *Event) error {
+	switch e.Type {
+	case EventCustomerCreated, EventCustomerUpdated, EventCustomerDeleted:
+	default:
+		return nil
+	}
+	c := &Customer{}
+	if err := e.Decode(c); err != nil {
+		return err
+	}
+	if e.Type == EventCustomerDeleted {
+		x.Remove(c.ID)
+	} else {
+		x.Add(c)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestNormalizeEmail will test that emails are lowercased and trimmed, and
+// that plus-address tags are only removed when configured.
+func TestNormalizeEmail(t *testing.T) {
+	for _, test := range []struct {
+		StripPlusTags bool
+		Email         string
+		Expected      string
+	}{
+		{false, " Jerry@Example.com ", "jerry@example.com"},
+		{false, "jerry+billing@example.com", "jerry+billing@example.com"},
+		{true, "Jerry+Billing@Example.com", "jerry@example.com"},
+		{true, "jerry", "jerry"},
+	} {
+		got := EmailNormalizer{test.StripPlusTags}.Normalize(test.Email)
+		if got != test.Expected {
+			t.Errorf("Expected %q normalized to %q, got %q", test.Email, test.Expected, got)
+		}
+	}
+}
+
+// TestEmailIndex will test that the index is built from the customer list,
+// and kept up to date from customer events.
+func TestEmailIndex(t *testing.T) {
+	_, done := mockServer(`{"object": "list", "has_more": false, "data": [
+		{"id": "cus_1", "email": "Jerry@example.com"},
+		{"id": "cus_2", "email": "jerry+work@example.com"},
+		{"id": "cus_3"}
+	]}`)
+	defer done()
+
+	index := NewEmailIndex(EmailNormalizer{StripPlusTags: true})
+	if err := index.Backfill(0); err != nil {
+		t.Errorf("Expected Backfill, got Error %s", err.Error())
+		return
+	}
+	if ids := index.Lookup("JERRY@example.com"); len(ids) != 2 || ids[0] != "cus_1" || ids[1] != "cus_2" {
+		t.Errorf("Expected cus_1 and cus_2, got %v", ids)
+	}
+
+	events := []*Event{
+		{Type: EventCustomerUpdated, Data: EventData{Object: json.RawMessage(`{"id": "cus_2", "email": "george@example.com"}`)}},
+		{Type: EventCustomerDeleted, Data: EventData{Object: json.RawMessage(`{"id": "cus_1", "email": "jerry@example.com"}`)}},
+		{Type: EventChargeSucceeded, Data: EventData{Object: json.RawMessage(`{"id": "ch_1"}`)}},
+	}
+	for _, e := range events {
+		if err := index.Apply(e); err != nil {
+			t.Errorf("Expected event to be applied, got Error %s", err.Error())
+		}
+	}
+	if ids := index.Lookup("jerry@example.com"); len(ids) != 0 {
+		t.Errorf("Expected no customers for jerry@example.com, got %v", ids)
+	}
+	if ids := index.Lookup("george@example.com"); len(ids) != 1 || ids[0] != "cus_2" {
+		t.Errorf("Expected cus_2 for george@example.com, got %v", ids)
+	}
+}
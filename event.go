@@ -0,0 +1,75 @@
+package stripe
+
+import (
+	"encoding/json"
+	"net/url"
+)
+
+// Event Types
+const (
+	EventCustomerCreated          = "customer.created"
+	EventCustomerUpdated          = "customer.updated"
+	EventCustomerDeleted          = "customer.deleted"
+	EventInvoicePaymentSucceeded  = "invoice.payment_succeeded"
+	EventInvoicePaymentFailed     = "invoice.payment_failed"
+	EventPlanUpdated              = "plan.updated"
+	EventSubscriptionDeleted      = "customer.subscription.deleted"
+	EventSubscriptionUpdated      = "customer.subscription.updated"
+	EventChargeSucceeded          = "charge.succeeded"
+	EventChargeDisputeCreated     = "charge.dispute.created"
+	EventChargeRefunded           = "charge.refunded"
+	EventInvoiceItemCreated       = "invoiceitem.created"
+	EventSubscriptionTrialWillEnd = "customer.subscription.trial_will_end"
+)
+
+// Event represents a change to an object in your account, such as a charge
+// succeeding or an invoice being paid. Events are delivered to webhooks and
+// can be queried for up to 30 days.
+//
+// see https://stripe.com/docs/api#event_object
+type Event struct {
+	ID       string    `json:"id"`
+	Type     string    `json:"type"`
+	Created  UnixTime  `json:"created"`
+	Livemode bool      `json:"livemode"`
+	Data     EventData `json:"data"`
+}
+
+// EventData holds the object an Event describes, along with the values of
+// any attributes that changed.
+type EventData struct {
+	Object             json.RawMessage        `json:"object"`
+	PreviousAttributes map[string]interface{} `json:"previous_attributes,omitempty"`
+}
+
+// Decode parses the object the event describes, storing the result in the
+// value pointed to by v.
+func (e *Event) Decode(v interface{}) error {
+	return _codec.Unmarshal(e.Data.Object, v)
+}
+
+// EventClient encapsulates operations for querying events using the Stripe
+// REST API.
+type EventClient struct{}
+
+// Retrieves the event with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_event
+func (EventClient) Get(id string) (*Event, error) {
+	res := &Event{}
+	return res, query("GET", "/events/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of Events of the given type (or all types, if empty) at the
+// specified range.
+//
+// see https://stripe.com/docs/api#list_events
+func (EventClient) List(typ string, limit int, before, after string) ([]*Event, bool, error) {
+	var data []*Event
+	params := listParams(limit, before, after)
+	if typ != "" {
+		params.Add("type", typ)
+	}
+	list, err := queryList("/events", params, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,144 @@
+package stripe
+
+import (
+	"bytes"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// File Purposes
+const (
+	FilePurposeDisputeEvidence   = "dispute_evidence"
+	FilePurposeIdentityDocument  = "identity_document"
+	FilePurposeBusinessLogo      = "business_logo"
+	FilePurposeBusinessIcon      = "business_icon"
+	FilePurposeCustomerSignature = "customer_signature"
+)
+
+// File represents a file uploaded to Stripe, such as evidence for a dispute
+// or an identity document for a connected account.
+//
+// see https://stripe.com/docs/api#file_object
+type File struct {
+	ID       string   `json:"id"`
+	Purpose  string   `json:"purpose"`
+	Filename string   `json:"filename,omitempty"`
+	Type     string   `json:"type,omitempty"`
+	Size     int      `json:"size"`
+	URL      string   `json:"url,omitempty"`
+	Created  UnixTime `json:"created"`
+}
+
+// FileParams encapsulates options for uploading a File.
+type FileParams struct {
+	// The purpose of the file, such as FilePurposeDisputeEvidence.
+	Purpose string
+
+	// The name of the file, whose extension is used to determine its type.
+	Filename string
+
+	// The contents of the file.
+	File io.Reader
+}
+
+// FileClient encapsulates operations for uploading and querying files using
+// the Stripe REST API.
+type FileClient struct{}
+
+// Uploads a new File. Files are sent as multipart/form-data to the Stripe
+// file upload URL, rather than to the API URL.
+//
+// see https://stripe.com/docs/api#create_file
+func (FileClient) Create(params *FileParams) (*File, error) {
+	res := &File{}
+	if _readOnly {
+		return res, &ReadOnlyError{"POST", "/files"}
+	}
+
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	if err := w.WriteField("purpose", params.Purpose); err != nil {
+		return res, err
+	}
+	part, err := w.CreateFormFile("file", params.Filename)
+	if err != nil {
+		return res, err
+	}
+	if _, err := io.Copy(part, params.File); err != nil {
+		return res, err
+	}
+	if err := w.Close(); err != nil {
+		return res, err
+	}
+
+	endpoint, err := url.Parse(_uploadUrl)
+	if err != nil {
+		return res, err
+	}
+	endpoint.Path = "/v1/files"
+	endpoint.User = url.User(_key)
+
+	req, err := http.NewRequest("POST", endpoint.String(), body)
+	if err != nil {
+		return res, err
+	}
+	req.Header.Set("Content-Type", w.FormDataContentType())
+	return res, send(req, "/files", res)
+}
+
+// Retrieves the File with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_file
+func (FileClient) Get(id string) (*File, error) {
+	res := &File{}
+	return res, query("GET", "/files/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of your Files with the given purpose, or of all Files if the
+// purpose is empty.
+//
+// see https://stripe.com/docs/api#list_files
+func (FileClient) List(purpose string, limit int, before, after string) ([]*File, bool, error) {
+	var data []*File
+	params := listParams(limit, before, after)
+	if purpose != "" {
+		params.Add("purpose", purpose)
+	}
+	list, err := queryList("/files", params, &data)
+	return data, list.More, err
+}
+
+// Dispute Evidence Fields that accept a file
+const (
+	EvidenceCancellationPolicy    = "cancellation_policy"
+	EvidenceCustomerCommunication = "customer_communication"
+	EvidenceCustomerSignature     = "customer_signature"
+	EvidenceDuplicateChargeDocs   = "duplicate_charge_documentation"
+	EvidenceReceipt               = "receipt"
+	EvidenceRefundPolicy          = "refund_policy"
+	EvidenceServiceDocumentation  = "service_documentation"
+	EvidenceShippingDocumentation = "shipping_documentation"
+	EvidenceUncategorizedFile     = "uncategorized_file"
+)
+
+// AttachDisputeEvidence uploads a file as dispute evidence, and attaches it
+// to the given evidence field (such as EvidenceReceipt) of the dispute with
+// the given ID. The file's purpose is set to FilePurposeDisputeEvidence. The
+// uploaded File is returned, even if attaching it fails, so that it can be
+// attached again without uploading it twice.
+func AttachDisputeEvidence(disputeID, field string, params *FileParams) (*File, error) {
+	upload := *params
+	upload.Purpose = FilePurposeDisputeEvidence
+	file, err := Files.Create(&upload)
+	if err != nil {
+		return nil, err
+	}
+
+	values := url.Values{"evidence[" + field + "]": {file.ID}}
+	res := &struct {
+		ID string `json:"id"`
+	}{}
+	return file, query("POST", "/disputes/"+url.QueryEscape(disputeID), values, res)
+}
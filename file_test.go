@@ -0,0 +1,103 @@
+package stripe
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// TestUploadFile will test that a file is uploaded as multipart/form-data to
+// the file upload URL.
+func TestUploadFile(t *testing.T) {
+	var purpose, filename, contents string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != "POST" || r.URL.Path != "/v1/files" {
+			t.Errorf("Expected POST /v1/files, got %s %s", r.Method, r.URL.Path)
+		}
+		purpose = r.FormValue("purpose")
+		if f, h, err := r.FormFile("file"); err == nil {
+			filename = h.Filename
+			b, _ := ioutil.ReadAll(f)
+			contents = string(b)
+		}
+		fmt.Fprint(w, `{"id": "file_1", "purpose": "dispute_evidence", "filename": "receipt.pdf", "size": 8}`)
+	}))
+	defer srv.Close()
+	prev := _uploadUrl
+	SetUploadUrl(srv.URL)
+	defer SetUploadUrl(prev)
+
+	file, err := Files.Create(&FileParams{
+		Purpose:  FilePurposeDisputeEvidence,
+		Filename: "receipt.pdf",
+		File:     strings.NewReader("%PDF-1.4"),
+	})
+	if err != nil {
+		t.Errorf("Expected File, got Error %s", err.Error())
+		return
+	}
+	if purpose != "dispute_evidence" || filename != "receipt.pdf" || contents != "%PDF-1.4" {
+		t.Errorf("Expected receipt.pdf uploaded as dispute evidence, got %q %q %q", purpose, filename, contents)
+	}
+	if file.ID != "file_1" || file.Size != 8 {
+		t.Errorf("Expected File file_1 of 8 bytes, got %v", file)
+	}
+}
+
+// TestListFiles will test that Files are listed from the API URL, filtered by
+// purpose.
+func TestListFiles(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "file_1", "purpose": "dispute_evidence"}]}`)
+	defer done()
+
+	files, _, err := Files.List(FilePurposeDisputeEvidence, 10, "", "")
+	if err != nil {
+		t.Errorf("Expected Files, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/files" || req.Form.Get("purpose") != "dispute_evidence" {
+		t.Errorf("Expected /v1/files filtered by purpose, got %s %v", req.Path, req.Form)
+	}
+	if len(files) != 1 {
+		t.Errorf("Expected 1 File, got %d", len(files))
+	}
+}
+
+// TestAttachDisputeEvidence will test that a file is uploaded as dispute
+// evidence, then attached to the dispute's evidence field.
+func TestAttachDisputeEvidence(t *testing.T) {
+	var uploaded bool
+	var attach *mockRequest
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		switch r.Path {
+		case "/v1/files":
+			uploaded = true
+			fmt.Fprint(w, `{"id": "file_1", "purpose": "dispute_evidence"}`)
+		case "/v1/disputes/dp_1":
+			attach = r
+			fmt.Fprint(w, `{"id": "dp_1", "evidence": {"receipt": "file_1"}}`)
+		}
+	})
+	defer done()
+	prev := _uploadUrl
+	SetUploadUrl(_url)
+	defer SetUploadUrl(prev)
+
+	file, err := AttachDisputeEvidence("dp_1", EvidenceReceipt, &FileParams{
+		Filename: "receipt.pdf",
+		File:     strings.NewReader("%PDF-1.4"),
+	})
+	if err != nil {
+		t.Errorf("Expected File, got Error %s", err.Error())
+		return
+	}
+	if !uploaded || file.ID != "file_1" {
+		t.Errorf("Expected File file_1 to be uploaded, got %v", file)
+	}
+	if attach == nil || attach.Method != "POST" || attach.Form.Get("evidence[receipt]") != "file_1" {
+		t.Errorf("Expected evidence[receipt]=file_1 to be posted to the dispute, got %v", attach)
+	}
+}
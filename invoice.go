@@ -1,8 +1,37 @@
 package stripe
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
+	"net/http"
 	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Invoice Line Item Types
+const (
+	LineItemInvoiceItem  = "invoiceitem"
+	LineItemSubscription = "subscription"
+)
+
+// Invoice Statuses
+const (
+	InvoiceDraft         = "draft"
+	InvoiceOpen          = "open"
+	InvoicePaid          = "paid"
+	InvoiceUncollectible = "uncollectible"
+	InvoiceVoid          = "void"
+)
+
+// Invoice Collection Methods
+const (
+	ChargeAutomatically = "charge_automatically"
+	SendInvoice         = "send_invoice"
 )
 
 // Invoice represents statements of what a customer owes for a particular
@@ -17,6 +46,13 @@ type Invoice struct {
 	Attempted          bool              `json:"attempted"`
 	Closed             bool              `json:"closed"`
 	Paid               bool              `json:"paid"`
+	Status             string            `json:"status,omitempty"`
+	CollectionMethod   string            `json:"collection_method,omitempty"`
+	DueDate            *UnixTime         `json:"due_date,omitempty"`
+	AutoAdvance        bool              `json:"auto_advance"`
+	Footer             string            `json:"footer,omitempty"`
+	HostedInvoiceURL   string            `json:"hosted_invoice_url,omitempty"`
+	InvoicePDF         string            `json:"invoice_pdf,omitempty"`
 	PeriodEnd          UnixTime          `json:"period_end"`
 	PeriodStart        UnixTime          `json:"period_start"`
 	Subtotal           int               `json:"subtotal"`
@@ -24,6 +60,8 @@ type Invoice struct {
 	Currency           string            `json:"currency"`
 	Charge             string            `json:"charge,omitempty"`
 	Customer           string            `json:"customer"`
+	CustomerTaxIDs     []*InvoiceTaxID   `json:"customer_tax_ids,omitempty"`
+	Subscription       string            `json:"subscription,omitempty"`
 	Date               UnixTime          `json:"date"`
 	Discount           *Discount         `json:"discount,omitempty"`
 	Lines              *InvoiceLines     `json:"lines"`
@@ -33,6 +71,36 @@ type Invoice struct {
 	Livemode           bool              `json:"livemode"`
 	Metadata           map[string]string `json:"metadata"`
 	Description        string            `json:"omitempty"`
+
+	// set for the upcoming invoice, which is a preview rather than an
+	// invoice that exists in Stripe
+	upcoming bool
+}
+
+// UnmarshalJSON decodes an Invoice, marking it as the upcoming invoice if it
+// has no ID, as is the case for the invoice returned by Upcoming and sent
+// with invoice.upcoming events.
+func (in *Invoice) UnmarshalJSON(data []byte) error {
+	type invoice Invoice
+	if err := json.Unmarshal(data, (*invoice)(in)); err != nil {
+		return err
+	}
+	in.upcoming = in.ID == "" || strings.HasPrefix(in.ID, "upcoming_")
+	return nil
+}
+
+// IsUpcoming returns true if the invoice is a preview of the customer's next
+// invoice, rather than one that exists in Stripe. The upcoming invoice has no
+// ID and no charge, so it cannot be retrieved, paid or otherwise acted on.
+func (in *Invoice) IsUpcoming() bool {
+	return in.upcoming
+}
+
+// InvoiceTaxID is a tax ID of the customer, as shown on an Invoice. The tax
+// IDs are copied from the customer's TaxIDs when the invoice is finalized.
+type InvoiceTaxID struct {
+	Type  string `json:"type"`
+	Value string `json:"value"`
 }
 
 // InvoiceLines represents an individual line items that is part of an invoice.
@@ -41,6 +109,18 @@ type InvoiceLines struct {
 	Data []*InvoiceLineItem `json:"data"`
 }
 
+// Len returns the number of line items in this page of the list.
+func (l *InvoiceLines) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the line items in this page of the list, reporting a warning
+// if the list has more results.
+func (l *InvoiceLines) Page() []*InvoiceLineItem {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
+
 type InvoiceLineItem struct {
 	ID          string            `json:"id"`
 	Livemode    bool              `json:"livemode"`
@@ -55,11 +135,74 @@ type InvoiceLineItem struct {
 	Quantity    int               `json:"quantity,omitempty"`
 }
 
+// IsSubscription returns true if the line item bills for a subscription, as
+// opposed to a one-off invoice item.
+func (l *InvoiceLineItem) IsSubscription() bool {
+	return l.Type == LineItemSubscription
+}
+
+// IsProration returns true if the line item is a proration adjustment, such
+// as the credit or charge for a mid-period plan change.
+func (l *InvoiceLineItem) IsProration() bool {
+	return l.Proration
+}
+
 type Period struct {
 	Start UnixTime `json:"start"`
 	End   UnixTime `json:"end"`
 }
 
+// InvoiceLineGroup is a group of an invoice's line items of the same type and
+// period, for rendering an invoice in sections.
+type InvoiceLineGroup struct {
+	// LineItemSubscription or LineItemInvoiceItem.
+	Type   string
+	Period Period
+	Lines  []*InvoiceLineItem
+
+	// The sum of the amounts of the lines, in cents.
+	Subtotal int
+}
+
+// GroupLines groups the invoice's line items by type, with subscriptions
+// before one-off invoice items, and then by period, in order of the start of
+// the period. Lines keep their order within a group. Only the line items
+// included in the invoice's page of lines are grouped.
+func (inv *Invoice) GroupLines() []*InvoiceLineGroup {
+	if inv.Lines == nil {
+		return nil
+	}
+	type key struct {
+		typ        string
+		start, end int64
+	}
+	var groups []*InvoiceLineGroup
+	index := make(map[key]*InvoiceLineGroup)
+	for _, line := range inv.Lines.Data {
+		k := key{line.Type, line.Period.Start.Unix(), line.Period.End.Unix()}
+		g, ok := index[k]
+		if !ok {
+			g = &InvoiceLineGroup{Type: line.Type, Period: line.Period}
+			index[k] = g
+			groups = append(groups, g)
+		}
+		g.Lines = append(g.Lines, line)
+		g.Subtotal += line.Amount
+	}
+
+	sort.SliceStable(groups, func(i, j int) bool {
+		a, b := groups[i], groups[j]
+		if a.Type != b.Type {
+			return a.Type == LineItemSubscription
+		}
+		if !a.Period.Start.Equal(b.Period.Start.Time) {
+			return a.Period.Start.Before(b.Period.Start.Time)
+		}
+		return a.Period.End.Before(b.Period.End.Time)
+	})
+	return groups
+}
+
 type InvoiceParams struct {
 	// The customer ID to invoice
 	Customer string
@@ -76,6 +219,25 @@ type InvoiceParams struct {
 
 	// (Optional) Boolean representing whether an invoice is closed or not.
 	Closed *bool
+
+	// (Optional) Either ChargeAutomatically, to charge the customer's default
+	// source, or SendInvoice, to email the invoice to the customer.
+	CollectionMethod string
+
+	// (Optional) The date on which payment of a SendInvoice invoice is due.
+	// Cannot be set together with DaysUntilDue.
+	DueDate *UnixTime
+
+	// (Optional) The number of days from creation until a SendInvoice invoice
+	// is due. Cannot be set together with DueDate.
+	DaysUntilDue int
+
+	// (Optional) Whether Stripe should automatically finalize and collect the
+	// invoice.
+	AutoAdvance *bool
+
+	// (Optional) A footer to display on the invoice.
+	Footer string
 }
 
 // InvoiceClient encapsulates operations for querying invoices using the Stripe
@@ -100,17 +262,173 @@ func (InvoiceClient) Update(id string, params *InvoiceParams) (*Invoice, error)
 	return res, query("POST", "/invoices/"+url.QueryEscape(id), invoiceValues(params), res)
 }
 
-func (InvoiceClient) Pay(id string) (*Invoice, error) {
+func (c InvoiceClient) Pay(id string) (*Invoice, error) {
+	return c.action(id, "pay")
+}
+
+// Finalizes the draft invoice with the given ID, so that it can be paid or
+// sent to the customer.
+//
+// see https://stripe.com/docs/api#finalize_invoice
+func (c InvoiceClient) Finalize(id string) (*Invoice, error) {
+	return c.action(id, "finalize")
+}
+
+// Voids the finalized invoice with the given ID. A voided invoice can no
+// longer be paid.
+//
+// see https://stripe.com/docs/api#void_invoice
+func (c InvoiceClient) Void(id string) (*Invoice, error) {
+	return c.action(id, "void")
+}
+
+// Sends the invoice with the given ID to the customer by email.
+//
+// see https://stripe.com/docs/api#send_invoice
+func (c InvoiceClient) Send(id string) (*Invoice, error) {
+	return c.action(id, "send")
+}
+
+// Marks the invoice with the given ID as uncollectible, for bad debt
+// accounting.
+//
+// see https://stripe.com/docs/api#mark_invoice_uncollectible
+func (c InvoiceClient) MarkUncollectible(id string) (*Invoice, error) {
+	return c.action(id, "mark_uncollectible")
+}
+
+// ErrInvoicePDFUnavailable is returned when downloading the PDF of an invoice
+// that has none, such as a draft invoice.
+var ErrInvoicePDFUnavailable = errors.New("stripe: invoice has no PDF")
+
+// DownloadPDF retrieves the invoice with the given ID and streams its PDF to
+// w. The download is cancelled if ctx is done.
+func (c InvoiceClient) DownloadPDF(ctx context.Context, id string, w io.Writer) error {
+	inv, err := c.Get(id)
+	if err != nil {
+		return err
+	}
+	if inv.InvoicePDF == "" {
+		return ErrInvoicePDFUnavailable
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "GET", inv.InvoicePDF, nil)
+	if err != nil {
+		return err
+	}
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if r.StatusCode != 200 {
+		return fmt.Errorf("stripe: downloading invoice PDF: %s", r.Status)
+	}
+	_, err = io.Copy(w, r.Body)
+	return err
+}
+
+func (InvoiceClient) action(id, name string) (*Invoice, error) {
 	res := &Invoice{}
-	return res, query("POST", fmt.Sprintf("/invoices/%s/pay", url.QueryEscape(id)), nil, res)
+	return res, query("POST", fmt.Sprintf("/invoices/%s/%s", url.QueryEscape(id), name), nil, res)
+}
+
+// UpcomingParams encapsulates options for previewing the upcoming invoice
+// with proposed changes to a subscription. Nothing is changed on the
+// subscription.
+type UpcomingParams struct {
+	// (Optional) The ID of the subscription to preview. If not set, the
+	// preview includes all of the customer's subscriptions.
+	Subscription string
+
+	// (Optional) The plan to preview switching the subscription to.
+	Plan string
+
+	// (Optional) The quantity to preview for the subscription.
+	Quantity *int
+
+	// (Optional) The items to preview on a multi-plan subscription.
+	Items []*UpcomingItem
+
+	// (Optional) The coupon to preview applying to the customer.
+	Coupon string
+
+	// (Optional) Whether to prorate the proposed changes. Default is true.
+	Prorate *bool
+
+	// (Optional) The time at which the proration is calculated. Use the same
+	// time when making the change to be billed the previewed amount.
+	ProrationDate *UnixTime
+}
+
+// UpcomingItem is a proposed change to one item of a subscription when
+// previewing the upcoming invoice.
+type UpcomingItem struct {
+	// (Optional) The ID of an existing subscription item to change or delete.
+	ID string
+
+	// (Optional) The plan for the item.
+	Plan string
+
+	// (Optional) The quantity of the item.
+	Quantity *int
+
+	// (Optional) Whether the existing item is removed.
+	Deleted bool
 }
 
-// Retrieves the upcoming invoice the given customer ID.
+// Retrieves the upcoming invoice the given customer ID, previewing any
+// proposed subscription changes in params, which may be nil.
 //
 // see https://stripe.com/docs/api#retrieve_customer_invoice
-func (InvoiceClient) Upcoming(customerID string) (*Invoice, error) {
+func (InvoiceClient) Upcoming(customerID string, params *UpcomingParams) (*Invoice, error) {
+	values := url.Values{"customer": {customerID}}
+	if params != nil {
+		appendUpcomingParams(values, params)
+	}
 	res := &Invoice{}
-	return res, query("GET", "/invoices/upcoming", url.Values{"customer": {customerID}}, res)
+	if err := query("GET", "/invoices/upcoming", values, res); err != nil {
+		return res, err
+	}
+	// mark the preview even if a Codec that bypasses UnmarshalJSON is used
+	res.upcoming = true
+	return res, nil
+}
+
+func appendUpcomingParams(values url.Values, params *UpcomingParams) {
+	if params.Subscription != "" {
+		values.Add("subscription", params.Subscription)
+	}
+	if params.Plan != "" {
+		values.Add("subscription_plan", params.Plan)
+	}
+	if params.Quantity != nil {
+		values.Add("subscription_quantity", strconv.Itoa(*params.Quantity))
+	}
+	for i, item := range params.Items {
+		p := fmt.Sprintf("subscription_items[%d]", i)
+		if item.ID != "" {
+			values.Add(p+"[id]", item.ID)
+		}
+		if item.Plan != "" {
+			values.Add(p+"[plan]", item.Plan)
+		}
+		if item.Quantity != nil {
+			values.Add(p+"[quantity]", strconv.Itoa(*item.Quantity))
+		}
+		if item.Deleted {
+			values.Add(p+"[deleted]", "true")
+		}
+	}
+	if params.Coupon != "" {
+		values.Add("coupon", params.Coupon)
+	}
+	if params.Prorate != nil {
+		values.Add("subscription_prorate", strconv.FormatBool(*params.Prorate))
+	}
+	if params.ProrationDate != nil {
+		values.Add("subscription_proration_date", strconv.FormatInt(params.ProrationDate.Unix(), 10))
+	}
 }
 
 // Returns a list of Invoices at the specified range.
@@ -128,17 +446,14 @@ func (c InvoiceClient) CustomerList(id string, limit int, before, after string)
 }
 
 func (InvoiceClient) list(id string, limit int, before, after string) ([]*Invoice, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Invoice
-	}{}
+	var data []*Invoice
 	params := listParams(limit, before, after)
 	// query for customer id, if provided
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/invoices", params, &res)
-	return res.Data, res.More, err
+	list, err := queryList("/invoices", params, &data)
+	return data, list.More, err
 }
 
 func invoiceValues(inv *InvoiceParams) url.Values {
@@ -155,6 +470,21 @@ func invoiceValues(inv *InvoiceParams) url.Values {
 	if inv.Closed != nil {
 		values.Add("closed", fmt.Sprintf("%t", *inv.Closed))
 	}
+	if inv.CollectionMethod != "" {
+		values.Add("collection_method", inv.CollectionMethod)
+	}
+	if inv.DueDate != nil {
+		values.Add("due_date", strconv.FormatInt(inv.DueDate.Unix(), 10))
+	}
+	if inv.DaysUntilDue > 0 {
+		values.Add("days_until_due", strconv.Itoa(inv.DaysUntilDue))
+	}
+	if inv.AutoAdvance != nil {
+		values.Add("auto_advance", strconv.FormatBool(*inv.AutoAdvance))
+	}
+	if inv.Footer != "" {
+		values.Add("footer", inv.Footer)
+	}
 	appendMetadata(values, inv.Metadata)
 	return values
 }
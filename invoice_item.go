@@ -19,6 +19,7 @@ type InvoiceItem struct {
 	Invoice      string            `json:"invoice,omitempty"`
 	Subscription string            `json:"subscription,omitempty"`
 	Proration    bool              `json:"proration"`
+	TaxRates     []*TaxRate        `json:"tax_rates,omitempty"`
 	Metadata     map[string]string `json:"metadata,omitempty"`
 	Livemode     bool              `json:"livemode"`
 }
@@ -31,8 +32,8 @@ type InvoiceItemParams struct {
 
 	// The integer amount in cents of the charge to be applied to the upcoming
 	// invoice. If you want to apply a credit to the customer's account, pass a
-	// negative amount.
-	Amount int
+	// negative amount. Required when creating an Invoice Item.
+	Amount *int
 
 	// 3-letter ISO code for currency.
 	Currency string
@@ -49,6 +50,10 @@ type InvoiceItemParams struct {
 	// (Optional) The ID of a subscription to add this invoice item to.
 	Subscription string
 
+	// (Optional) The IDs of the Tax Rates to apply to this invoice item,
+	// replacing any already set.
+	TaxRates []string
+
 	Metadata map[string]string
 }
 
@@ -62,10 +67,12 @@ type InvoiceItemClient struct{}
 func (InvoiceItemClient) Create(params *InvoiceItemParams) (*InvoiceItem, error) {
 	item := InvoiceItem{}
 	values := url.Values{
-		"amount":   {strconv.Itoa(params.Amount)},
 		"currency": {params.Currency},
 		"customer": {params.Customer},
 	}
+	if params.Amount != nil {
+		values.Add("amount", strconv.Itoa(*params.Amount))
+	}
 
 	// add optional parameters
 	if params.Description != "" {
@@ -77,6 +84,9 @@ func (InvoiceItemClient) Create(params *InvoiceItemParams) (*InvoiceItem, error)
 	if params.Subscription != "" {
 		values.Add("subscription", params.Subscription)
 	}
+	for _, id := range params.TaxRates {
+		values.Add("tax_rates[]", id)
+	}
 	appendMetadata(values, params.Metadata)
 
 	err := query("POST", "/invoiceitems", values, &item)
@@ -104,8 +114,11 @@ func (InvoiceItemClient) Update(id string, params *InvoiceItemParams) (*InvoiceI
 	if params.Description != "" {
 		values.Add("description", params.Description)
 	}
-	if params.Amount != 0 {
-		values.Add("invoice", strconv.Itoa(params.Amount))
+	if params.Amount != nil {
+		values.Add("amount", strconv.Itoa(*params.Amount))
+	}
+	for _, id := range params.TaxRates {
+		values.Add("tax_rates[]", id)
 	}
 	appendMetadata(values, params.Metadata)
 
@@ -140,11 +153,11 @@ func (c InvoiceItemClient) CustomerList(id string, limit int, before, after stri
 }
 
 func (InvoiceItemClient) list(id string, limit int, before, after string) ([]*InvoiceItem, error) {
-	res := struct{ Data []*InvoiceItem }{}
+	var data []*InvoiceItem
 	params := listParams(limit, before, after)
 	if id != "" {
 		params.Add("customer", id)
 	}
-	err := query("GET", "/invoiceitems", params, &res)
-	return res.Data, err
+	_, err := queryList("/invoiceitems", params, &data)
+	return data, err
 }
@@ -0,0 +1,236 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestInvoiceLineItemTypes will test that subscription and proration line
+// items are identified.
+func TestInvoiceLineItemTypes(t *testing.T) {
+	inv := Invoice{}
+	json.Unmarshal([]byte(`{"lines": {"data": [
+		{"id": "sub_1", "type": "subscription"},
+		{"id": "ii_1", "type": "invoiceitem", "proration": true},
+		{"id": "ii_2", "type": "invoiceitem"}
+	]}}`), &inv)
+
+	tests := []struct {
+		Subscription bool
+		Proration    bool
+	}{
+		{true, false},
+		{false, true},
+		{false, false},
+	}
+	for i, test := range tests {
+		line := inv.Lines.Data[i]
+		if line.IsSubscription() != test.Subscription {
+			t.Errorf("Expected %s IsSubscription %v, got %v", line.ID, test.Subscription, line.IsSubscription())
+		}
+		if line.IsProration() != test.Proration {
+			t.Errorf("Expected %s IsProration %v, got %v", line.ID, test.Proration, line.IsProration())
+		}
+	}
+}
+
+// TestInvoiceActions will test that each invoice lifecycle action is posted
+// to its endpoint.
+func TestInvoiceActions(t *testing.T) {
+	req, done := mockServer(`{"id": "in_1", "status": "void"}`)
+	defer done()
+
+	for path, action := range map[string]func(string) (*Invoice, error){
+		"/v1/invoices/in_1/pay":                Invoices.Pay,
+		"/v1/invoices/in_1/finalize":           Invoices.Finalize,
+		"/v1/invoices/in_1/void":               Invoices.Void,
+		"/v1/invoices/in_1/send":               Invoices.Send,
+		"/v1/invoices/in_1/mark_uncollectible": Invoices.MarkUncollectible,
+	} {
+		inv, err := action("in_1")
+		if err != nil {
+			t.Errorf("Expected Invoice, got Error %s", err.Error())
+			continue
+		}
+		if req.Method != "POST" || req.Path != path {
+			t.Errorf("Expected POST %s, got %s %s", path, req.Method, req.Path)
+		}
+		if inv.Status != InvoiceVoid {
+			t.Errorf("Expected Invoice status %s, got %s", InvoiceVoid, inv.Status)
+		}
+	}
+}
+
+// TestCreateSendInvoice will test that the collection method, due date and
+// other invoicing options are sent when creating an Invoice.
+func TestCreateSendInvoice(t *testing.T) {
+	req, done := mockServer(`{"id": "in_1", "collection_method": "send_invoice", "due_date": 1500000000, "auto_advance": true}`)
+	defer done()
+
+	inv, err := Invoices.Create(&InvoiceParams{
+		Customer:         "cus_1",
+		CollectionMethod: SendInvoice,
+		DaysUntilDue:     30,
+		AutoAdvance:      Bool(true),
+		Footer:           "Thank you for your business",
+	})
+	if err != nil {
+		t.Errorf("Expected Invoice, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"customer":          "cus_1",
+		"collection_method": "send_invoice",
+		"days_until_due":    "30",
+		"auto_advance":      "true",
+		"footer":            "Thank you for your business",
+	})
+	if _, ok := req.Form["due_date"]; ok {
+		t.Errorf("Expected due_date to be omitted")
+	}
+	if inv.CollectionMethod != SendInvoice || inv.DueDate == nil || inv.DueDate.Unix() != 1500000000 {
+		t.Errorf("Expected send_invoice Invoice due at 1500000000, got %v", inv)
+	}
+}
+
+// TestDownloadInvoicePDF will test that an invoice's PDF is streamed from
+// its invoice_pdf URL.
+func TestDownloadInvoicePDF(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/invoices/in_1":
+			fmt.Fprintf(w, `{"id": "in_1", "hosted_invoice_url": "%[1]s/i/in_1", "invoice_pdf": "%[1]s/i/in_1/pdf"}`, srv.URL)
+		case "/v1/invoices/in_2":
+			fmt.Fprint(w, `{"id": "in_2", "status": "draft"}`)
+		case "/i/in_1/pdf":
+			fmt.Fprint(w, "%PDF-1.4")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	prev := _url
+	SetUrl(srv.URL)
+	defer SetUrl(prev)
+
+	var buf bytes.Buffer
+	if err := Invoices.DownloadPDF(context.Background(), "in_1", &buf); err != nil {
+		t.Errorf("Expected Invoice PDF, got Error %s", err.Error())
+	}
+	if buf.String() != "%PDF-1.4" {
+		t.Errorf("Expected Invoice PDF contents, got %q", buf.String())
+	}
+
+	if err := Invoices.DownloadPDF(context.Background(), "in_2", &buf); err != ErrInvoicePDFUnavailable {
+		t.Errorf("Expected ErrInvoicePDFUnavailable, got %v", err)
+	}
+}
+
+// TestUpcomingInvoicePreview will test that proposed subscription changes are
+// sent when previewing the upcoming invoice.
+func TestUpcomingInvoicePreview(t *testing.T) {
+	req, done := mockServer(`{"amount_due": 1500, "lines": {"data": [{"type": "invoiceitem", "proration": true, "amount": 500}]}}`)
+	defer done()
+
+	inv, err := Invoices.Upcoming("cus_1", &UpcomingParams{
+		Subscription: "sub_1",
+		Plan:         "gold",
+		Items: []*UpcomingItem{
+			{ID: "si_1", Deleted: true},
+			{Plan: "seats", Quantity: Int(3)},
+		},
+		Coupon:        "SUMMER",
+		ProrationDate: &UnixTime{time.Unix(1500000000, 0)},
+	})
+	if err != nil {
+		t.Errorf("Expected Invoice, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "GET" || req.Path != "/v1/invoices/upcoming" {
+		t.Errorf("Expected GET /v1/invoices/upcoming, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"customer":                        "cus_1",
+		"subscription":                    "sub_1",
+		"subscription_plan":               "gold",
+		"subscription_items[0][id]":       "si_1",
+		"subscription_items[0][deleted]":  "true",
+		"subscription_items[1][plan]":     "seats",
+		"subscription_items[1][quantity]": "3",
+		"coupon":                          "SUMMER",
+		"subscription_proration_date":     "1500000000",
+	})
+	if inv.AmountDue != 1500 {
+		t.Errorf("Expected Amount Due 1500, got %d", inv.AmountDue)
+	}
+	if !inv.IsUpcoming() {
+		t.Errorf("Expected the preview to be marked upcoming")
+	}
+}
+
+// TestInvoiceIsUpcoming will test that invoices are marked upcoming when
+// decoded without an ID.
+func TestInvoiceIsUpcoming(t *testing.T) {
+	for data, upcoming := range map[string]bool{
+		`{"id": "in_1", "charge": "ch_1"}`:    false,
+		`{"amount_due": 1500}`:                true,
+		`{"id": "upcoming_in_1", "total": 0}`: true,
+	} {
+		inv := &Invoice{}
+		if err := json.Unmarshal([]byte(data), inv); err != nil {
+			t.Errorf("Expected Invoice, got Error %s", err.Error())
+			continue
+		}
+		if inv.IsUpcoming() != upcoming {
+			t.Errorf("Expected IsUpcoming %v for %s, got %v", upcoming, data, !upcoming)
+		}
+	}
+	if (&Invoice{}).IsUpcoming() {
+		t.Errorf("Expected a zero Invoice not to be upcoming")
+	}
+}
+
+// TestInvoiceGroupLines will test that line items are grouped by type and
+// period, with subtotals for each group.
+func TestInvoiceGroupLines(t *testing.T) {
+	inv := Invoice{}
+	json.Unmarshal([]byte(`{"lines": {"data": [
+		{"id": "ii_1", "type": "invoiceitem", "amount": 500, "period": {"start": 1500000000, "end": 1500000000}},
+		{"id": "ii_2", "type": "invoiceitem", "proration": true, "amount": -300, "period": {"start": 1499000000, "end": 1500000000}},
+		{"id": "sub_1", "type": "subscription", "amount": 2000, "period": {"start": 1500000000, "end": 1502678400}},
+		{"id": "ii_3", "type": "invoiceitem", "proration": true, "amount": 700, "period": {"start": 1499000000, "end": 1500000000}}
+	]}}`), &inv)
+
+	groups := inv.GroupLines()
+	if len(groups) != 3 {
+		t.Errorf("Expected 3 groups, got %d", len(groups))
+		return
+	}
+	tests := []struct {
+		Type     string
+		Start    int64
+		Lines    int
+		Subtotal int
+	}{
+		{LineItemSubscription, 1500000000, 1, 2000},
+		{LineItemInvoiceItem, 1499000000, 2, 400},
+		{LineItemInvoiceItem, 1500000000, 1, 500},
+	}
+	for i, test := range tests {
+		g := groups[i]
+		if g.Type != test.Type || g.Period.Start.Unix() != test.Start || len(g.Lines) != test.Lines || g.Subtotal != test.Subtotal {
+			t.Errorf("Expected group %d of %d %s lines from %d with subtotal %d, got %d %s lines from %d with subtotal %d",
+				i, test.Lines, test.Type, test.Start, test.Subtotal, len(g.Lines), g.Type, g.Period.Start.Unix(), g.Subtotal)
+		}
+	}
+	if groups[1].Lines[0].ID != "ii_2" {
+		t.Errorf("Expected lines to keep their order, got %s first", groups[1].Lines[0].ID)
+	}
+}
@@ -0,0 +1,323 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Issuing Cardholder Types
+const (
+	CardholderIndividual = "individual"
+	CardholderCompany    = "company"
+)
+
+// Issuing Cardholder Statuses
+const (
+	CardholderActive   = "active"
+	CardholderInactive = "inactive"
+	CardholderBlocked  = "blocked"
+)
+
+// Issuing Card Types
+const (
+	IssuingCardPhysical = "physical"
+	IssuingCardVirtual  = "virtual"
+)
+
+// Issuing Card Statuses
+const (
+	IssuingCardActive   = "active"
+	IssuingCardInactive = "inactive"
+	IssuingCardCanceled = "canceled"
+)
+
+// Issuing Card Cancellation Reasons
+const (
+	IssuingCardLost   = "lost"
+	IssuingCardStolen = "stolen"
+)
+
+// Spending Limit Intervals
+const (
+	SpendingPerAuthorization = "per_authorization"
+	SpendingDaily            = "daily"
+	SpendingWeekly           = "weekly"
+	SpendingMonthly          = "monthly"
+	SpendingYearly           = "yearly"
+	SpendingAllTime          = "all_time"
+)
+
+// IssuingSpendingControls restricts how an issued card, or all of a
+// cardholder's cards, may be used. Categories are merchant category names,
+// such as "taxicabs_limousines".
+type IssuingSpendingControls struct {
+	// Only authorizations in these categories are allowed, if any are set.
+	AllowedCategories []string `json:"allowed_categories,omitempty"`
+
+	// Authorizations in these categories are declined.
+	BlockedCategories []string `json:"blocked_categories,omitempty"`
+
+	SpendingLimits []*SpendingLimit `json:"spending_limits,omitempty"`
+}
+
+// SpendingLimit limits the amount that may be spent over an interval,
+// optionally only in the given categories.
+type SpendingLimit struct {
+	Amount     int      `json:"amount"`
+	Interval   string   `json:"interval"`
+	Categories []string `json:"categories,omitempty"`
+}
+
+// appendSpendingControls adds the spending controls to values, nested under
+// spending_controls.
+func appendSpendingControls(values url.Values, sc *IssuingSpendingControls) {
+	for _, c := range sc.AllowedCategories {
+		values.Add("spending_controls[allowed_categories][]", c)
+	}
+	for _, c := range sc.BlockedCategories {
+		values.Add("spending_controls[blocked_categories][]", c)
+	}
+	for i, limit := range sc.SpendingLimits {
+		p := "spending_controls[spending_limits][" + strconv.Itoa(i) + "]"
+		values.Add(p+"[amount]", strconv.Itoa(limit.Amount))
+		values.Add(p+"[interval]", limit.Interval)
+		for _, c := range limit.Categories {
+			values.Add(p+"[categories][]", c)
+		}
+	}
+}
+
+// IssuingCardholder represents a person or business to whom cards are
+// issued.
+//
+// see https://stripe.com/docs/api#issuing_cardholder_object
+type IssuingCardholder struct {
+	ID          string `json:"id"`
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Email       string `json:"email,omitempty"`
+	PhoneNumber string `json:"phone_number,omitempty"`
+	Billing     struct {
+		Address *Address `json:"address"`
+	} `json:"billing"`
+	Status           string                   `json:"status"`
+	SpendingControls *IssuingSpendingControls `json:"spending_controls,omitempty"`
+	Created          UnixTime                 `json:"created"`
+	Livemode         bool                     `json:"livemode"`
+	Metadata         map[string]string        `json:"metadata,omitempty"`
+}
+
+// IssuingCardholderParams encapsulates options for creating and updating
+// Issuing Cardholders.
+type IssuingCardholderParams struct {
+	// Either CardholderIndividual or CardholderCompany. Only used when
+	// creating, when it is required.
+	Type string
+
+	// The cardholder's name, as printed on their cards. Only used when
+	// creating, when it is required.
+	Name string
+
+	// (Optional) The cardholder's email address and phone number.
+	Email       string
+	PhoneNumber string
+
+	// The cardholder's billing address. Required when creating.
+	BillingAddress *Address
+
+	// (Optional) The status of the cardholder, such as CardholderInactive.
+	Status string
+
+	// (Optional) Restrictions applied to all of the cardholder's cards.
+	SpendingControls *IssuingSpendingControls
+
+	Metadata map[string]string
+}
+
+// IssuingCardholderClient encapsulates operations for creating, updating and
+// querying Issuing cardholders using the Stripe REST API.
+type IssuingCardholderClient struct{}
+
+// Creates a new Cardholder.
+//
+// see https://stripe.com/docs/api#create_issuing_cardholder
+func (c IssuingCardholderClient) Create(params *IssuingCardholderParams) (*IssuingCardholder, error) {
+	values := c.values(params)
+	values.Add("type", params.Type)
+	values.Add("name", params.Name)
+	res := &IssuingCardholder{}
+	return res, query("POST", "/issuing/cardholders", values, res)
+}
+
+// Retrieves the Cardholder with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_issuing_cardholder
+func (IssuingCardholderClient) Get(id string) (*IssuingCardholder, error) {
+	res := &IssuingCardholder{}
+	return res, query("GET", "/issuing/cardholders/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Cardholder with the given ID.
+//
+// see https://stripe.com/docs/api#update_issuing_cardholder
+func (c IssuingCardholderClient) Update(id string, params *IssuingCardholderParams) (*IssuingCardholder, error) {
+	res := &IssuingCardholder{}
+	return res, query("POST", "/issuing/cardholders/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Returns a list of your Cardholders.
+//
+// see https://stripe.com/docs/api#list_issuing_cardholders
+func (IssuingCardholderClient) List(limit int, before, after string) ([]*IssuingCardholder, bool, error) {
+	var data []*IssuingCardholder
+	list, err := queryList("/issuing/cardholders", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (IssuingCardholderClient) values(params *IssuingCardholderParams) url.Values {
+	values := make(url.Values)
+	if params.Email != "" {
+		values.Add("email", params.Email)
+	}
+	if params.PhoneNumber != "" {
+		values.Add("phone_number", params.PhoneNumber)
+	}
+	if params.BillingAddress != nil {
+		appendAddress(values, "billing[address]", params.BillingAddress)
+	}
+	if params.Status != "" {
+		values.Add("status", params.Status)
+	}
+	if params.SpendingControls != nil {
+		appendSpendingControls(values, params.SpendingControls)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// IssuingCard represents a physical or virtual card issued to a
+// Cardholder.
+//
+// see https://stripe.com/docs/api#issuing_card_object
+type IssuingCard struct {
+	ID                 string                   `json:"id"`
+	Cardholder         *IssuingCardholder       `json:"cardholder"`
+	Brand              string                   `json:"brand"`
+	Currency           string                   `json:"currency"`
+	Type               string                   `json:"type"`
+	Status             string                   `json:"status"`
+	Last4              string                   `json:"last4"`
+	ExpMonth           int                      `json:"exp_month"`
+	ExpYear            int                      `json:"exp_year"`
+	CancellationReason string                   `json:"cancellation_reason,omitempty"`
+	ReplacementFor     string                   `json:"replacement_for,omitempty"`
+	SpendingControls   *IssuingSpendingControls `json:"spending_controls,omitempty"`
+	Created            UnixTime                 `json:"created"`
+	Livemode           bool                     `json:"livemode"`
+	Metadata           map[string]string        `json:"metadata,omitempty"`
+}
+
+// IssuingCardParams encapsulates options for creating and updating Issuing
+// Cards.
+type IssuingCardParams struct {
+	// The ID of the Cardholder the card is issued to. Only used when
+	// creating, when it is required.
+	Cardholder string
+
+	// The currency of the card, and whether it is IssuingCardPhysical or
+	// IssuingCardVirtual. Only used when creating, when both are required.
+	Currency string
+	Type     string
+
+	// (Optional) The status of the card. Cards are inactive when created
+	// unless set to IssuingCardActive. Canceling a card is permanent.
+	Status string
+
+	// (Optional) Why the card was canceled, such as IssuingCardLost. Only
+	// used when Status is IssuingCardCanceled.
+	CancellationReason string
+
+	// (Optional) Restrictions applied to the card, in addition to those of
+	// its cardholder.
+	SpendingControls *IssuingSpendingControls
+
+	Metadata map[string]string
+}
+
+// IssuingCardClient encapsulates operations for issuing, updating and
+// querying Issuing cards using the Stripe REST API.
+type IssuingCardClient struct{}
+
+// Issues a new Card to a Cardholder.
+//
+// see https://stripe.com/docs/api#create_issuing_card
+func (c IssuingCardClient) Create(params *IssuingCardParams) (*IssuingCard, error) {
+	values := c.values(params)
+	values.Add("cardholder", params.Cardholder)
+	values.Add("currency", params.Currency)
+	values.Add("type", params.Type)
+	res := &IssuingCard{}
+	return res, query("POST", "/issuing/cards", values, res)
+}
+
+// Retrieves the Card with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_issuing_card
+func (IssuingCardClient) Get(id string) (*IssuingCard, error) {
+	res := &IssuingCard{}
+	return res, query("GET", "/issuing/cards/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Card with the given ID.
+//
+// see https://stripe.com/docs/api#update_issuing_card
+func (c IssuingCardClient) Update(id string, params *IssuingCardParams) (*IssuingCard, error) {
+	res := &IssuingCard{}
+	return res, query("POST", "/issuing/cards/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Activate activates the Card with the given ID, so that it can be used.
+func (c IssuingCardClient) Activate(id string) (*IssuingCard, error) {
+	return c.Update(id, &IssuingCardParams{Status: IssuingCardActive})
+}
+
+// Deactivate deactivates the Card with the given ID, declining all of its
+// authorizations until it is activated again.
+func (c IssuingCardClient) Deactivate(id string) (*IssuingCard, error) {
+	return c.Update(id, &IssuingCardParams{Status: IssuingCardInactive})
+}
+
+// Cancel permanently cancels the Card with the given ID, for the given
+// reason, such as IssuingCardLost, or for no reason if empty.
+func (c IssuingCardClient) Cancel(id, reason string) (*IssuingCard, error) {
+	return c.Update(id, &IssuingCardParams{Status: IssuingCardCanceled, CancellationReason: reason})
+}
+
+// Returns a list of your Cards, optionally only those issued to the
+// Cardholder with the given ID.
+//
+// see https://stripe.com/docs/api#list_issuing_cards
+func (IssuingCardClient) List(cardholderID string, limit int, before, after string) ([]*IssuingCard, bool, error) {
+	var data []*IssuingCard
+	params := listParams(limit, before, after)
+	if cardholderID != "" {
+		params.Add("cardholder", cardholderID)
+	}
+	list, err := queryList("/issuing/cards", params, &data)
+	return data, list.More, err
+}
+
+func (IssuingCardClient) values(params *IssuingCardParams) url.Values {
+	values := make(url.Values)
+	if params.Status != "" {
+		values.Add("status", params.Status)
+	}
+	if params.CancellationReason != "" {
+		values.Add("cancellation_reason", params.CancellationReason)
+	}
+	if params.SpendingControls != nil {
+		appendSpendingControls(values, params.SpendingControls)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
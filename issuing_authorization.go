@@ -0,0 +1,252 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Issuing Authorization Statuses
+const (
+	AuthorizationPending  = "pending"
+	AuthorizationClosed   = "closed"
+	AuthorizationReversed = "reversed"
+)
+
+// Issuing Transaction Types
+const (
+	IssuingTransactionCapture = "capture"
+	IssuingTransactionRefund  = "refund"
+)
+
+// Issuing Dispute Reasons
+const (
+	IssuingDisputeFraudulent                = "fraudulent"
+	IssuingDisputeNotReceived               = "not_received"
+	IssuingDisputeDuplicate                 = "duplicate"
+	IssuingDisputeMerchandiseNotAsDescribed = "merchandise_not_as_described"
+	IssuingDisputeServiceNotAsDescribed     = "service_not_as_described"
+	IssuingDisputeCanceled                  = "canceled"
+	IssuingDisputeOther                     = "other"
+)
+
+// Issuing Dispute Statuses
+const (
+	IssuingDisputeUnsubmitted = "unsubmitted"
+	IssuingDisputeSubmitted   = "submitted"
+	IssuingDisputeWon         = "won"
+	IssuingDisputeLost        = "lost"
+	IssuingDisputeExpired     = "expired"
+)
+
+// MerchantData describes the merchant at which an issued card was used.
+type MerchantData struct {
+	Name       string `json:"name"`
+	Category   string `json:"category"`
+	City       string `json:"city,omitempty"`
+	State      string `json:"state,omitempty"`
+	Country    string `json:"country,omitempty"`
+	PostalCode string `json:"postal_code,omitempty"`
+	NetworkID  string `json:"network_id"`
+}
+
+// IssuingAuthorization represents an attempt to use an issued card. A
+// pending authorization must be approved or declined in real time, usually
+// in response to an issuing_authorization.request event.
+//
+// see https://stripe.com/docs/api#issuing_authorization_object
+type IssuingAuthorization struct {
+	ID                  string            `json:"id"`
+	Amount              int               `json:"amount"`
+	Currency            string            `json:"currency"`
+	MerchantAmount      int               `json:"merchant_amount"`
+	MerchantCurrency    string            `json:"merchant_currency"`
+	Approved            bool              `json:"approved"`
+	Status              string            `json:"status"`
+	AuthorizationMethod string            `json:"authorization_method"`
+	Card                *IssuingCard      `json:"card"`
+	Cardholder          string            `json:"cardholder,omitempty"`
+	MerchantData        *MerchantData     `json:"merchant_data"`
+	Created             UnixTime          `json:"created"`
+	Livemode            bool              `json:"livemode"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+}
+
+// IssuingAuthorizationClient encapsulates operations for approving,
+// declining and querying Issuing authorizations using the Stripe REST API.
+type IssuingAuthorizationClient struct{}
+
+// Retrieves the Authorization with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_issuing_authorization
+func (IssuingAuthorizationClient) Get(id string) (*IssuingAuthorization, error) {
+	res := &IssuingAuthorization{}
+	return res, query("GET", "/issuing/authorizations/"+url.QueryEscape(id), nil, res)
+}
+
+// Approves the pending Authorization with the given ID, optionally for less
+// than the amount requested if amount is not nil.
+//
+// see https://stripe.com/docs/api#approve_issuing_authorization
+func (IssuingAuthorizationClient) Approve(id string, amount *int) (*IssuingAuthorization, error) {
+	values := make(url.Values)
+	if amount != nil {
+		values.Add("amount", strconv.Itoa(*amount))
+	}
+	res := &IssuingAuthorization{}
+	return res, query("POST", "/issuing/authorizations/"+url.QueryEscape(id)+"/approve", values, res)
+}
+
+// Declines the pending Authorization with the given ID.
+//
+// see https://stripe.com/docs/api#decline_issuing_authorization
+func (IssuingAuthorizationClient) Decline(id string) (*IssuingAuthorization, error) {
+	res := &IssuingAuthorization{}
+	return res, query("POST", "/issuing/authorizations/"+url.QueryEscape(id)+"/decline", nil, res)
+}
+
+// Returns a list of your Authorizations, optionally only those with the
+// given status, such as AuthorizationPending.
+//
+// see https://stripe.com/docs/api#list_issuing_authorizations
+func (IssuingAuthorizationClient) List(status string, limit int, before, after string) ([]*IssuingAuthorization, bool, error) {
+	var data []*IssuingAuthorization
+	params := listParams(limit, before, after)
+	if status != "" {
+		params.Add("status", status)
+	}
+	list, err := queryList("/issuing/authorizations", params, &data)
+	return data, list.More, err
+}
+
+// IssuingTransaction represents money moving to or from a merchant as a
+// result of using an issued card, such as the capture of an Authorization.
+//
+// see https://stripe.com/docs/api#issuing_transaction_object
+type IssuingTransaction struct {
+	ID               string            `json:"id"`
+	Type             string            `json:"type"`
+	Amount           int               `json:"amount"`
+	Currency         string            `json:"currency"`
+	MerchantAmount   int               `json:"merchant_amount"`
+	MerchantCurrency string            `json:"merchant_currency"`
+	Authorization    string            `json:"authorization,omitempty"`
+	Card             string            `json:"card"`
+	Cardholder       string            `json:"cardholder,omitempty"`
+	Dispute          string            `json:"dispute,omitempty"`
+	MerchantData     *MerchantData     `json:"merchant_data"`
+	Created          UnixTime          `json:"created"`
+	Livemode         bool              `json:"livemode"`
+	Metadata         map[string]string `json:"metadata,omitempty"`
+}
+
+// IssuingTransactionClient encapsulates operations for querying Issuing
+// transactions using the Stripe REST API.
+type IssuingTransactionClient struct{}
+
+// Retrieves the Transaction with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_issuing_transaction
+func (IssuingTransactionClient) Get(id string) (*IssuingTransaction, error) {
+	res := &IssuingTransaction{}
+	return res, query("GET", "/issuing/transactions/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of your Transactions, optionally only those of the Card
+// with the given ID.
+//
+// see https://stripe.com/docs/api#list_issuing_transactions
+func (IssuingTransactionClient) List(cardID string, limit int, before, after string) ([]*IssuingTransaction, bool, error) {
+	var data []*IssuingTransaction
+	params := listParams(limit, before, after)
+	if cardID != "" {
+		params.Add("card", cardID)
+	}
+	list, err := queryList("/issuing/transactions", params, &data)
+	return data, list.More, err
+}
+
+// IssuingDispute represents a challenge to a Transaction made with an
+// issued card. A dispute is unsubmitted until it is submitted with Submit.
+//
+// see https://stripe.com/docs/api#issuing_dispute_object
+type IssuingDispute struct {
+	ID          string `json:"id"`
+	Transaction string `json:"transaction"`
+	Amount      int    `json:"amount"`
+	Currency    string `json:"currency"`
+	Status      string `json:"status"`
+	Evidence    struct {
+		Reason string `json:"reason"`
+	} `json:"evidence"`
+	Created  UnixTime          `json:"created"`
+	Livemode bool              `json:"livemode"`
+	Metadata map[string]string `json:"metadata,omitempty"`
+}
+
+// IssuingDisputeParams encapsulates options for creating an Issuing
+// Dispute.
+type IssuingDisputeParams struct {
+	// The ID of the Transaction being disputed.
+	Transaction string
+
+	// The reason for the dispute, such as IssuingDisputeFraudulent.
+	Reason string
+
+	// (Optional) An explanation of why the transaction is disputed.
+	Explanation string
+
+	// (Optional) The amount disputed. Defaults to the full amount of the
+	// transaction.
+	Amount *int
+
+	Metadata map[string]string
+}
+
+// IssuingDisputeClient encapsulates operations for creating, submitting and
+// querying Issuing disputes using the Stripe REST API.
+type IssuingDisputeClient struct{}
+
+// Creates a new, unsubmitted Dispute of a Transaction.
+//
+// see https://stripe.com/docs/api#create_issuing_dispute
+func (IssuingDisputeClient) Create(params *IssuingDisputeParams) (*IssuingDispute, error) {
+	values := url.Values{
+		"transaction":      {params.Transaction},
+		"evidence[reason]": {params.Reason},
+	}
+	if params.Explanation != "" {
+		values.Add("evidence["+params.Reason+"][explanation]", params.Explanation)
+	}
+	if params.Amount != nil {
+		values.Add("amount", strconv.Itoa(*params.Amount))
+	}
+	appendMetadata(values, params.Metadata)
+	res := &IssuingDispute{}
+	return res, query("POST", "/issuing/disputes", values, res)
+}
+
+// Retrieves the Dispute with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_issuing_dispute
+func (IssuingDisputeClient) Get(id string) (*IssuingDispute, error) {
+	res := &IssuingDispute{}
+	return res, query("GET", "/issuing/disputes/"+url.QueryEscape(id), nil, res)
+}
+
+// Submits the Dispute with the given ID to the card network. Its evidence
+// cannot be changed once it is submitted.
+//
+// see https://stripe.com/docs/api#submit_issuing_dispute
+func (IssuingDisputeClient) Submit(id string) (*IssuingDispute, error) {
+	res := &IssuingDispute{}
+	return res, query("POST", "/issuing/disputes/"+url.QueryEscape(id)+"/submit", nil, res)
+}
+
+// Returns a list of your Disputes.
+//
+// see https://stripe.com/docs/api#list_issuing_disputes
+func (IssuingDisputeClient) List(limit int, before, after string) ([]*IssuingDispute, bool, error) {
+	var data []*IssuingDispute
+	list, err := queryList("/issuing/disputes", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
@@ -0,0 +1,52 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestApproveIssuingAuthorization will test that a pending Authorization is
+// approved for a partial amount.
+func TestApproveIssuingAuthorization(t *testing.T) {
+	req, done := mockServer(`{"id": "iauth_1", "approved": true, "status": "pending", "amount": 800, "card": {"id": "ic_1"}, "merchant_data": {"name": "Cafe", "category": "eating_places_restaurants"}}`)
+	defer done()
+
+	auth, err := IssuingAuthorizations.Approve("iauth_1", Int(800))
+	if err != nil {
+		t.Errorf("Expected Authorization, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/issuing/authorizations/iauth_1/approve" {
+		t.Errorf("Expected POST /v1/issuing/authorizations/iauth_1/approve, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("amount"); got != "800" {
+		t.Errorf("Expected param amount=800, got %q", got)
+	}
+	if !auth.Approved || auth.Card == nil || auth.Card.ID != "ic_1" || auth.MerchantData == nil || auth.MerchantData.Name != "Cafe" {
+		t.Errorf("Expected approved Authorization of ic_1 at Cafe, got %+v", auth)
+	}
+}
+
+// TestCreateIssuingDispute will test that a Dispute is created with its
+// evidence nested under its reason.
+func TestCreateIssuingDispute(t *testing.T) {
+	req, done := mockServer(`{"id": "idp_1", "transaction": "ipi_1", "status": "unsubmitted", "evidence": {"reason": "fraudulent"}}`)
+	defer done()
+
+	dispute, err := IssuingDisputes.Create(&IssuingDisputeParams{
+		Transaction: "ipi_1",
+		Reason:      IssuingDisputeFraudulent,
+		Explanation: "Card was stolen",
+	})
+	if err != nil {
+		t.Errorf("Expected Dispute, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"transaction":                       "ipi_1",
+		"evidence[reason]":                  "fraudulent",
+		"evidence[fraudulent][explanation]": "Card was stolen",
+	})
+	if dispute.Status != IssuingDisputeUnsubmitted || dispute.Evidence.Reason != IssuingDisputeFraudulent {
+		t.Errorf("Expected unsubmitted fraudulent Dispute, got %+v", dispute)
+	}
+}
@@ -0,0 +1,71 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateIssuingCardholder will test that a Cardholder is created with
+// their billing address and spending controls.
+func TestCreateIssuingCardholder(t *testing.T) {
+	req, done := mockServer(`{"id": "ich_1", "type": "individual", "name": "Jenny Rosen", "status": "active", "billing": {"address": {"line1": "1 Main St"}}, "spending_controls": {"blocked_categories": ["gambling"], "spending_limits": [{"amount": 50000, "interval": "monthly"}]}}`)
+	defer done()
+
+	holder, err := IssuingCardholders.Create(&IssuingCardholderParams{
+		Type:           CardholderIndividual,
+		Name:           "Jenny Rosen",
+		BillingAddress: &Address{Line1: "1 Main St", Country: "US"},
+		SpendingControls: &IssuingSpendingControls{
+			BlockedCategories: []string{"gambling"},
+			SpendingLimits: []*SpendingLimit{
+				{Amount: 50000, Interval: SpendingMonthly},
+				{Amount: 5000, Interval: SpendingPerAuthorization, Categories: []string{"taxicabs_limousines"}},
+			},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Cardholder, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/issuing/cardholders" {
+		t.Errorf("Expected POST /v1/issuing/cardholders, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"type":                    "individual",
+		"name":                    "Jenny Rosen",
+		"billing[address][line1]": "1 Main St",
+		"spending_controls[blocked_categories][]":             "gambling",
+		"spending_controls[spending_limits][0][amount]":       "50000",
+		"spending_controls[spending_limits][0][interval]":     "monthly",
+		"spending_controls[spending_limits][1][interval]":     "per_authorization",
+		"spending_controls[spending_limits][1][categories][]": "taxicabs_limousines",
+	})
+	sc := holder.SpendingControls
+	if sc == nil || len(sc.SpendingLimits) != 1 || sc.SpendingLimits[0].Interval != SpendingMonthly {
+		t.Errorf("Expected monthly spending limit, got %+v", sc)
+	}
+	if holder.Billing.Address == nil || holder.Billing.Address.Line1 != "1 Main St" {
+		t.Errorf("Expected billing address 1 Main St, got %+v", holder.Billing.Address)
+	}
+}
+
+// TestCancelIssuingCard will test that canceling a Card updates its status
+// with the cancellation reason.
+func TestCancelIssuingCard(t *testing.T) {
+	req, done := mockServer(`{"id": "ic_1", "status": "canceled", "cancellation_reason": "lost", "cardholder": {"id": "ich_1"}}`)
+	defer done()
+
+	card, err := IssuingCards.Cancel("ic_1", IssuingCardLost)
+	if err != nil {
+		t.Errorf("Expected Card, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/issuing/cards/ic_1" {
+		t.Errorf("Expected POST /v1/issuing/cards/ic_1, got %s %s", req.Method, req.Path)
+	}
+	if req.Form.Get("status") != "canceled" || req.Form.Get("cancellation_reason") != "lost" {
+		t.Errorf("Expected card canceled as lost, got %v", req.Form)
+	}
+	if card.Status != IssuingCardCanceled || card.Cardholder == nil || card.Cardholder.ID != "ich_1" {
+		t.Errorf("Expected canceled Card of ich_1, got %+v", card)
+	}
+}
@@ -0,0 +1,72 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Level3 holds the itemized order data sent with a card charge to qualify
+// for lower interchange rates on business and purchasing cards. Amounts are
+// in cents, and the line items must add up to the charge's amount, less
+// the shipping amount.
+//
+// see https://stripe.com/docs/level3
+type Level3 struct {
+	// Your reference for the order, such as its purchase order number.
+	// Required.
+	MerchantReference string `json:"merchant_reference"`
+
+	// (Optional) The customer's reference for the order.
+	CustomerReference string `json:"customer_reference,omitempty"`
+
+	// (Optional) The postal codes the order was shipped to and from, and
+	// the amount charged for shipping.
+	ShippingAddressZip string `json:"shipping_address_zip,omitempty"`
+	ShippingFromZip    string `json:"shipping_from_zip,omitempty"`
+	ShippingAmount     int    `json:"shipping_amount,omitempty"`
+
+	LineItems []*Level3LineItem `json:"line_items"`
+}
+
+// Level3LineItem is an item of a Level3 order. The item's total is its unit
+// cost times its quantity, plus its tax, less its discount.
+type Level3LineItem struct {
+	// A code identifying the product, of up to 12 characters.
+	ProductCode string `json:"product_code"`
+
+	// A description of the product, of up to 26 characters.
+	ProductDescription string `json:"product_description"`
+
+	UnitCost       int `json:"unit_cost"`
+	Quantity       int `json:"quantity"`
+	TaxAmount      int `json:"tax_amount"`
+	DiscountAmount int `json:"discount_amount"`
+}
+
+// appendLevel3 adds the Level3 data to values, nested under level3, with
+// each line item indexed by its position (e.g. level3[line_items][0][...]).
+func appendLevel3(values url.Values, l *Level3) {
+	values.Add("level3[merchant_reference]", l.MerchantReference)
+	if l.CustomerReference != "" {
+		values.Add("level3[customer_reference]", l.CustomerReference)
+	}
+	if l.ShippingAddressZip != "" {
+		values.Add("level3[shipping_address_zip]", l.ShippingAddressZip)
+	}
+	if l.ShippingFromZip != "" {
+		values.Add("level3[shipping_from_zip]", l.ShippingFromZip)
+	}
+	if l.ShippingAmount > 0 {
+		values.Add("level3[shipping_amount]", strconv.Itoa(l.ShippingAmount))
+	}
+	for i, item := range l.LineItems {
+		p := fmt.Sprintf("level3[line_items][%d]", i)
+		values.Add(p+"[product_code]", item.ProductCode)
+		values.Add(p+"[product_description]", item.ProductDescription)
+		values.Add(p+"[unit_cost]", strconv.Itoa(item.UnitCost))
+		values.Add(p+"[quantity]", strconv.Itoa(item.Quantity))
+		values.Add(p+"[tax_amount]", strconv.Itoa(item.TaxAmount))
+		values.Add(p+"[discount_amount]", strconv.Itoa(item.DiscountAmount))
+	}
+}
@@ -0,0 +1,92 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Mandate Statuses
+const (
+	MandateActive   = "active"
+	MandateInactive = "inactive"
+	MandatePending  = "pending"
+)
+
+// Mandate Types
+const (
+	MandateMultiUse  = "multi_use"
+	MandateSingleUse = "single_use"
+)
+
+// Mandate Acceptance Types
+const (
+	AcceptanceOnline  = "online"
+	AcceptanceOffline = "offline"
+)
+
+// Mandate records a customer's authorization to debit their account with a
+// PaymentMethod, such as a SEPA or Bacs direct debit. Mandates should be
+// kept as evidence of the authorization.
+//
+// see https://stripe.com/docs/api#mandate_object
+type Mandate struct {
+	ID                   string                 `json:"id"`
+	Type                 string                 `json:"type"`
+	Status               string                 `json:"status"`
+	PaymentMethod        string                 `json:"payment_method"`
+	PaymentMethodDetails *MandateMethodDetails  `json:"payment_method_details"`
+	CustomerAcceptance   *MandateAcceptance     `json:"customer_acceptance"`
+	SingleUse            *MandateSingleUseLimit `json:"single_use,omitempty"`
+	Livemode             bool                   `json:"livemode"`
+}
+
+// MandateAcceptance describes how and when the customer accepted a Mandate.
+type MandateAcceptance struct {
+	Type       string   `json:"type"`
+	AcceptedAt UnixTime `json:"accepted_at"`
+
+	// Set for online acceptance.
+	Online *struct {
+		IPAddress string `json:"ip_address"`
+		UserAgent string `json:"user_agent"`
+	} `json:"online,omitempty"`
+}
+
+// MandateMethodDetails describes the Mandate as specific to the type of its
+// PaymentMethod. Only the details field matching the Type is populated.
+type MandateMethodDetails struct {
+	Type      string                 `json:"type"`
+	SEPADebit *MandateDebitReference `json:"sepa_debit,omitempty"`
+	BACSDebit *MandateDebitReference `json:"bacs_debit,omitempty"`
+}
+
+// MandateDebitReference identifies a direct debit Mandate to the customer's
+// bank.
+type MandateDebitReference struct {
+	// The unique reference of the mandate, shown to the customer.
+	Reference string `json:"reference"`
+
+	// The URL of the mandate document the customer accepted.
+	URL string `json:"url"`
+
+	// The status of the mandate on the Bacs network. Only set for Bacs.
+	NetworkStatus string `json:"network_status,omitempty"`
+}
+
+// MandateSingleUseLimit is the amount a single use Mandate authorizes.
+type MandateSingleUseLimit struct {
+	Amount   int    `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MandateClient encapsulates operations for querying mandates using the
+// Stripe REST API. Mandates are created by Stripe when a customer accepts
+// them while setting up or paying with a PaymentMethod.
+type MandateClient struct{}
+
+// Retrieves the Mandate with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_mandate
+func (MandateClient) Get(id string) (*Mandate, error) {
+	res := &Mandate{}
+	return res, query("GET", "/mandates/"+url.QueryEscape(id), nil, res)
+}
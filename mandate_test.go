@@ -0,0 +1,46 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestGetMandate will test that a Mandate is decoded with its acceptance and
+// debit reference.
+func TestGetMandate(t *testing.T) {
+	req, done := mockServer(`{"id": "mandate_1", "type": "multi_use", "status": "active", "payment_method": "pm_1", "customer_acceptance": {"type": "online", "accepted_at": 1500000000, "online": {"ip_address": "127.0.0.1", "user_agent": "Go"}}, "payment_method_details": {"type": "sepa_debit", "sepa_debit": {"reference": "REF123", "url": "https://stripe.com/m/1"}}}`)
+	defer done()
+
+	mandate, err := Mandates.Get("mandate_1")
+	if err != nil {
+		t.Errorf("Expected Mandate, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "GET" || req.Path != "/v1/mandates/mandate_1" {
+		t.Errorf("Expected GET /v1/mandates/mandate_1, got %s %s", req.Method, req.Path)
+	}
+	if mandate.Status != MandateActive || mandate.PaymentMethod != "pm_1" {
+		t.Errorf("Expected active Mandate for pm_1, got %+v", mandate)
+	}
+	if a := mandate.CustomerAcceptance; a == nil || a.Type != AcceptanceOnline || a.Online == nil || a.Online.IPAddress != "127.0.0.1" || a.AcceptedAt.Unix() != 1500000000 {
+		t.Errorf("Expected online acceptance from 127.0.0.1, got %+v", a)
+	}
+	if d := mandate.PaymentMethodDetails; d == nil || d.SEPADebit == nil || d.SEPADebit.Reference != "REF123" {
+		t.Errorf("Expected SEPA debit reference REF123, got %+v", d)
+	}
+}
+
+// TestSetupIntentMandate will test that a SetupIntent references the Mandate
+// accepted while setting up its PaymentMethod.
+func TestSetupIntentMandate(t *testing.T) {
+	_, done := mockServer(`{"id": "seti_1", "status": "succeeded", "mandate": "mandate_1"}`)
+	defer done()
+
+	intent, err := SetupIntents.Get("seti_1")
+	if err != nil {
+		t.Errorf("Expected SetupIntent, got Error %s", err.Error())
+		return
+	}
+	if intent.Mandate != "mandate_1" {
+		t.Errorf("Expected SetupIntent Mandate mandate_1, got %q", intent.Mandate)
+	}
+}
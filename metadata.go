@@ -0,0 +1,93 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// MetadataLimits holds the limits on metadata that are checked before a
+// request is sent to Stripe.
+type MetadataLimits struct {
+	// The maximum number of keys an object's metadata may have.
+	MaxKeys int
+
+	// The maximum length, in characters, of a metadata key.
+	MaxKeyLength int
+
+	// The maximum length, in characters, of a metadata value.
+	MaxValueLength int
+}
+
+// DefaultMetadataLimits are the metadata limits currently enforced by Stripe.
+var DefaultMetadataLimits = MetadataLimits{
+	MaxKeys:        50,
+	MaxKeyLength:   40,
+	MaxValueLength: 500,
+}
+
+// the metadata limits checked before each request
+var _metadataLimits = DefaultMetadataLimits
+
+// SetMetadataLimits overrides the metadata limits checked before each
+// request, should Stripe's limits change.
+func SetMetadataLimits(limits MetadataLimits) {
+	_metadataLimits = limits
+}
+
+// MetadataError is returned when metadata exceeds one of the MetadataLimits.
+type MetadataError struct {
+	// The key that violates the limit. Empty if there are too many keys.
+	Key string
+
+	// A description of the limit that was exceeded.
+	Reason string
+}
+
+func (e *MetadataError) Error() string {
+	if e.Key == "" {
+		return "stripe: metadata " + e.Reason
+	}
+	return fmt.Sprintf("stripe: metadata key %q %s", e.Key, e.Reason)
+}
+
+// ValidateMetadata checks the metadata against the current limits, returning
+// a *MetadataError naming the first key (in sorted order) that violates them.
+func ValidateMetadata(meta map[string]string) error {
+	limits := _metadataLimits
+	if limits.MaxKeys > 0 && len(meta) > limits.MaxKeys {
+		return &MetadataError{Reason: fmt.Sprintf("has %d keys, more than the limit of %d", len(meta), limits.MaxKeys)}
+	}
+
+	keys := make([]string, 0, len(meta))
+	for k := range meta {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if n := len([]rune(k)); limits.MaxKeyLength > 0 && n > limits.MaxKeyLength {
+			return &MetadataError{Key: k, Reason: fmt.Sprintf("is %d characters, longer than the limit of %d", n, limits.MaxKeyLength)}
+		}
+		if n := len([]rune(meta[k])); limits.MaxValueLength > 0 && n > limits.MaxValueLength {
+			return &MetadataError{Key: k, Reason: fmt.Sprintf("has a value of %d characters, longer than the limit of %d", n, limits.MaxValueLength)}
+		}
+	}
+	return nil
+}
+
+// validateMetadataValues checks the top-level metadata[...] params of a
+// request against the current limits.
+func validateMetadataValues(values url.Values) error {
+	var meta map[string]string
+	for k, v := range values {
+		if !strings.HasPrefix(k, "metadata[") || !strings.HasSuffix(k, "]") {
+			continue
+		}
+		if meta == nil {
+			meta = make(map[string]string)
+		}
+		meta[k[len("metadata["):len(k)-1]] = strings.Join(v, "")
+	}
+	return ValidateMetadata(meta)
+}
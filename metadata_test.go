@@ -0,0 +1,55 @@
+package stripe
+
+import (
+	"strings"
+	"testing"
+)
+
+// TestValidateMetadata will test that metadata exceeding the limits is
+// rejected with an error naming the offending key.
+func TestValidateMetadata(t *testing.T) {
+	tests := []struct {
+		Meta map[string]string
+		Key  string
+		OK   bool
+	}{
+		{map[string]string{"order_id": "6735"}, "", true},
+		{map[string]string{strings.Repeat("k", 41): "v"}, strings.Repeat("k", 41), false},
+		{map[string]string{"order_id": "6735", "note": strings.Repeat("v", 501)}, "note", false},
+	}
+	for _, test := range tests {
+		err := ValidateMetadata(test.Meta)
+		if test.OK {
+			if err != nil {
+				t.Errorf("Expected valid Metadata, got Error %s", err.Error())
+			}
+			continue
+		}
+		merr, ok := err.(*MetadataError)
+		if !ok {
+			t.Errorf("Expected MetadataError, got %v", err)
+			continue
+		}
+		if merr.Key != test.Key {
+			t.Errorf("Expected MetadataError for key %q, got %q", test.Key, merr.Key)
+		}
+	}
+}
+
+// TestMetadataLimitsOverride will test that the limits can be changed, and
+// that requests with invalid metadata are rejected before being sent.
+func TestMetadataLimitsOverride(t *testing.T) {
+	SetMetadataLimits(MetadataLimits{MaxKeys: 1, MaxKeyLength: 40, MaxValueLength: 500})
+	defer SetMetadataLimits(DefaultMetadataLimits)
+
+	req, done := mockServer(`{"id": "cus_1"}`)
+	defer done()
+
+	_, err := Customers.Update("cus_1", &CustomerParams{Metadata: map[string]string{"a": "1", "b": "2"}})
+	if _, ok := err.(*MetadataError); !ok {
+		t.Errorf("Expected MetadataError, got %v", err)
+	}
+	if req.Method != "" {
+		t.Errorf("Expected no request to be sent, got %s %s", req.Method, req.Path)
+	}
+}
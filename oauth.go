@@ -0,0 +1,124 @@
+package stripe
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OAuth Scopes
+const (
+	ScopeReadOnly  = "read_only"
+	ScopeReadWrite = "read_write"
+)
+
+// OAuthToken holds the credentials returned when a Standard account is
+// connected to your platform with OAuth, or when they are refreshed.
+//
+// see https://stripe.com/docs/connect/oauth-reference#post-token
+type OAuthToken struct {
+	AccessToken          string `json:"access_token"`
+	RefreshToken         string `json:"refresh_token"`
+	TokenType            string `json:"token_type"`
+	Scope                string `json:"scope"`
+	StripeUserID         string `json:"stripe_user_id"`
+	StripePublishableKey string `json:"stripe_publishable_key"`
+	Livemode             bool   `json:"livemode"`
+}
+
+// OAuthError is returned when Stripe Connect rejects an OAuth request, such
+// as when an authorization code has expired or was already used.
+type OAuthError struct {
+	// The OAuth error code, such as "invalid_grant".
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (e *OAuthError) Error() string {
+	return fmt.Sprintf("stripe: oauth %s: %s", e.Code, e.Description)
+}
+
+// OAuthClient encapsulates operations for connecting and disconnecting
+// Standard accounts with Stripe Connect OAuth. Requests are sent to the
+// Connect URL rather than the API URL, authenticated with the platform's
+// secret key.
+type OAuthClient struct{}
+
+// Token exchanges the authorization code sent to your redirect URI, after
+// the user connects their account, for the account's tokens.
+//
+// see https://stripe.com/docs/connect/oauth-reference#post-token
+func (OAuthClient) Token(code string) (*OAuthToken, error) {
+	values := url.Values{
+		"grant_type": {"authorization_code"},
+		"code":       {code},
+	}
+	res := &OAuthToken{}
+	return res, oauthQuery("/oauth/token", values, res)
+}
+
+// Refresh uses a refresh token to create a new access token for the
+// connected account, optionally with a narrower scope, such as
+// ScopeReadOnly.
+//
+// see https://stripe.com/docs/connect/oauth-reference#post-token
+func (OAuthClient) Refresh(refreshToken, scope string) (*OAuthToken, error) {
+	values := url.Values{
+		"grant_type":    {"refresh_token"},
+		"refresh_token": {refreshToken},
+	}
+	if scope != "" {
+		values.Add("scope", scope)
+	}
+	res := &OAuthToken{}
+	return res, oauthQuery("/oauth/token", values, res)
+}
+
+// Deauthorize disconnects the account with the given ID from the platform
+// with the given client ID, revoking its tokens.
+//
+// see https://stripe.com/docs/connect/oauth-reference#post-deauthorize
+func (OAuthClient) Deauthorize(clientID, stripeUserID string) error {
+	values := url.Values{
+		"client_id":      {clientID},
+		"stripe_user_id": {stripeUserID},
+	}
+	res := &struct {
+		StripeUserID string `json:"stripe_user_id"`
+	}{}
+	return oauthQuery("/oauth/deauthorize", values, res)
+}
+
+// oauthQuery posts the values to the Stripe Connect OAuth endpoint at path,
+// decoding the response into v. OAuth endpoints report failures as an
+// OAuthError rather than an Error.
+func oauthQuery(path string, values url.Values, v interface{}) error {
+	if _readOnly {
+		return &ReadOnlyError{"POST", path}
+	}
+
+	endpoint, err := url.Parse(_connectUrl)
+	if err != nil {
+		return err
+	}
+	endpoint.Path = path
+	endpoint.User = url.User(_key)
+
+	// Log request if logging enabled
+	if _log {
+		fmt.Println("REQUEST: ", "POST", endpoint.String())
+		fmt.Println(values.Encode())
+	}
+
+	req, err := http.NewRequest("POST", endpoint.String(), strings.NewReader(values.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return sendDecodingErrors(req, path, v, func(status int, body []byte) error {
+		oauthErr := &OAuthError{}
+		_codec.Unmarshal(body, oauthErr)
+		return oauthErr
+	})
+}
@@ -0,0 +1,107 @@
+package stripe
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+)
+
+// mockConnect starts a mock Stripe Connect server which sends the given
+// status and response, returning the form posted to it.
+func mockConnect(status int, resp string) (*mockRequest, func()) {
+	req := &mockRequest{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		*req = mockRequest{Method: r.Method, Path: r.URL.Path, Form: form}
+		w.WriteHeader(status)
+		w.Write([]byte(resp))
+	}))
+	prev := _connectUrl
+	SetConnectUrl(srv.URL)
+	return req, func() {
+		SetConnectUrl(prev)
+		srv.Close()
+	}
+}
+
+// TestOAuthToken will test that an authorization code is exchanged for the
+// connected account's tokens.
+func TestOAuthToken(t *testing.T) {
+	req, done := mockConnect(200, `{"access_token": "sk_test_1", "refresh_token": "rt_1", "token_type": "bearer", "scope": "read_write", "stripe_user_id": "acct_1", "stripe_publishable_key": "pk_test_1"}`)
+	defer done()
+
+	token, err := OAuth.Token("ac_1")
+	if err != nil {
+		t.Errorf("Expected OAuth Token, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/oauth/token" {
+		t.Errorf("Expected POST /oauth/token, got %s %s", req.Method, req.Path)
+	}
+	if req.Form.Get("grant_type") != "authorization_code" || req.Form.Get("code") != "ac_1" {
+		t.Errorf("Expected authorization_code grant of ac_1, got %v", req.Form)
+	}
+	if token.StripeUserID != "acct_1" || token.RefreshToken != "rt_1" || token.Scope != ScopeReadWrite {
+		t.Errorf("Expected read_write tokens for acct_1, got %+v", token)
+	}
+}
+
+// TestOAuthError will test that a rejected grant is returned as an
+// OAuthError.
+func TestOAuthError(t *testing.T) {
+	req, done := mockConnect(400, `{"error": "invalid_grant", "error_description": "Authorization code expired"}`)
+	defer done()
+
+	_, err := OAuth.Refresh("rt_1", ScopeReadOnly)
+	if req.Form.Get("grant_type") != "refresh_token" || req.Form.Get("scope") != "read_only" {
+		t.Errorf("Expected read_only refresh_token grant, got %v", req.Form)
+	}
+	oauthErr, ok := err.(*OAuthError)
+	if !ok {
+		t.Errorf("Expected OAuthError, got %v", err)
+		return
+	}
+	if oauthErr.Code != "invalid_grant" {
+		t.Errorf("Expected invalid_grant, got %q", oauthErr.Code)
+	}
+}
+
+// TestOAuthSend will test that OAuth requests are sent like any other: with
+// the API version, counted in the request stats, and with their warnings
+// reported.
+func TestOAuthSend(t *testing.T) {
+	var version string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		version = r.Header.Get("Stripe-Version")
+		w.Header().Set("Warning", `299 - "client_id is deprecated"`)
+		w.Write([]byte(`{"stripe_user_id": "acct_1"}`))
+	}))
+	defer srv.Close()
+	prev := _connectUrl
+	SetConnectUrl(srv.URL)
+	defer SetConnectUrl(prev)
+
+	var warnings []*APIWarning
+	SetWarningHandler(func(w *APIWarning) {
+		warnings = append(warnings, w)
+	})
+	defer SetWarningHandler(nil)
+
+	before := Stats()[StatsKey{"oauth", "POST"}].Requests
+	if err := OAuth.Deauthorize("ca_1", "acct_1"); err != nil {
+		t.Errorf("Expected deauthorization, got Error %s", err.Error())
+		return
+	}
+	if version != apiVersion {
+		t.Errorf("Expected Stripe-Version %s, got %q", apiVersion, version)
+	}
+	if after := Stats()[StatsKey{"oauth", "POST"}].Requests; after != before+1 {
+		t.Errorf("Expected 1 oauth request counted, got %d", after-before)
+	}
+	if len(warnings) != 1 || warnings[0].Message != "client_id is deprecated" {
+		t.Errorf("Expected client_id warning, got %v", warnings)
+	}
+}
@@ -0,0 +1,249 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// PaymentMethod Types
+const (
+	PaymentMethodCard          = "card"
+	PaymentMethodUSBankAccount = "us_bank_account"
+	PaymentMethodSEPADebit     = "sepa_debit"
+)
+
+// PaymentMethod represents a customer's payment instrument. Only the details
+// field matching the Type (Card, USBankAccount or SEPADebit) is populated.
+//
+// see https://stripe.com/docs/api#payment_method_object
+type PaymentMethod struct {
+	ID             string                             `json:"id"`
+	Type           string                             `json:"type"`
+	Customer       string                             `json:"customer,omitempty"`
+	BillingDetails *BillingDetails                    `json:"billing_details,omitempty"`
+	Card           *PaymentMethodCardDetails          `json:"card,omitempty"`
+	USBankAccount  *PaymentMethodUSBankAccountDetails `json:"us_bank_account,omitempty"`
+	SEPADebit      *PaymentMethodSEPADebitDetails     `json:"sepa_debit,omitempty"`
+	Created        UnixTime                           `json:"created"`
+	Livemode       bool                               `json:"livemode"`
+	Metadata       map[string]string                  `json:"metadata,omitempty"`
+}
+
+// BillingDetails holds the billing information associated with a
+// PaymentMethod.
+type BillingDetails struct {
+	Name    string   `json:"name,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Phone   string   `json:"phone,omitempty"`
+	Address *Address `json:"address,omitempty"`
+}
+
+// PaymentMethodCardDetails describes a card PaymentMethod.
+type PaymentMethodCardDetails struct {
+	Brand       string `json:"brand"`
+	Country     string `json:"country,omitempty"`
+	ExpMonth    int    `json:"exp_month"`
+	ExpYear     int    `json:"exp_year"`
+	Fingerprint string `json:"fingerprint"`
+	Funding     string `json:"funding"`
+	Last4       string `json:"last4"`
+}
+
+// PaymentMethodUSBankAccountDetails describes a us_bank_account PaymentMethod.
+type PaymentMethodUSBankAccountDetails struct {
+	AccountHolderType string `json:"account_holder_type,omitempty"`
+	AccountType       string `json:"account_type,omitempty"`
+	BankName          string `json:"bank_name,omitempty"`
+	Fingerprint       string `json:"fingerprint"`
+	Last4             string `json:"last4"`
+	RoutingNumber     string `json:"routing_number"`
+}
+
+// PaymentMethodSEPADebitDetails describes a sepa_debit PaymentMethod.
+type PaymentMethodSEPADebitDetails struct {
+	BankCode    string `json:"bank_code,omitempty"`
+	BranchCode  string `json:"branch_code,omitempty"`
+	Country     string `json:"country"`
+	Fingerprint string `json:"fingerprint"`
+	Last4       string `json:"last4"`
+}
+
+// PaymentMethodParams encapsulates options for creating and updating
+// PaymentMethods.
+type PaymentMethodParams struct {
+	// The type of the PaymentMethod. Required when creating.
+	Type string
+
+	// (Optional) The card details, when Type is card. Only the number,
+	// expiration and CVC are sent; when updating, only the expiration.
+	Card *CardParams
+
+	// (Optional) The bank account details, when Type is us_bank_account.
+	// When updating, only the account holder type and account type are sent.
+	USBankAccount *USBankAccountParams
+
+	// (Optional) The IBAN of the account to debit, when Type is sepa_debit.
+	IBAN string
+
+	// (Optional) Billing information associated with the PaymentMethod.
+	BillingDetails *BillingDetails
+
+	Metadata map[string]string
+}
+
+// USBankAccountParams encapsulates the details of a us_bank_account
+// PaymentMethod.
+type USBankAccountParams struct {
+	AccountNumber     string
+	RoutingNumber     string
+	AccountHolderType string
+	AccountType       string
+}
+
+// PaymentMethodClient encapsulates operations for creating, updating,
+// attaching and querying PaymentMethods using the Stripe REST API.
+type PaymentMethodClient struct{}
+
+// Creates a new PaymentMethod.
+//
+// see https://stripe.com/docs/api#create_payment_method
+func (c PaymentMethodClient) Create(params *PaymentMethodParams) (*PaymentMethod, error) {
+	res := &PaymentMethod{}
+	return res, query("POST", "/payment_methods", c.createValues(params), res)
+}
+
+// Retrieves the PaymentMethod with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_payment_method
+func (PaymentMethodClient) Get(id string) (*PaymentMethod, error) {
+	res := &PaymentMethod{}
+	return res, query("GET", "/payment_methods/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the billing details, card expiration, bank account type or
+// metadata of the PaymentMethod with the given ID. Other params are ignored.
+//
+// see https://stripe.com/docs/api#update_payment_method
+func (c PaymentMethodClient) Update(id string, params *PaymentMethodParams) (*PaymentMethod, error) {
+	res := &PaymentMethod{}
+	return res, query("POST", "/payment_methods/"+url.QueryEscape(id), c.updateValues(params), res)
+}
+
+// Attaches the PaymentMethod with the given ID to a customer.
+//
+// see https://stripe.com/docs/api#attach_payment_method
+func (PaymentMethodClient) Attach(id, customerID string) (*PaymentMethod, error) {
+	values := url.Values{"customer": {customerID}}
+	res := &PaymentMethod{}
+	return res, query("POST", "/payment_methods/"+url.QueryEscape(id)+"/attach", values, res)
+}
+
+// Detaches the PaymentMethod with the given ID from its customer. A detached
+// PaymentMethod can no longer be used.
+//
+// see https://stripe.com/docs/api#detach_payment_method
+func (PaymentMethodClient) Detach(id string) (*PaymentMethod, error) {
+	res := &PaymentMethod{}
+	return res, query("POST", "/payment_methods/"+url.QueryEscape(id)+"/detach", nil, res)
+}
+
+// Returns a list of the PaymentMethods of the given type at the specified
+// range, only those of the customer with the given ID if it is not empty.
+//
+// see https://stripe.com/docs/api#list_payment_methods
+func (PaymentMethodClient) List(customerID, typ string, limit int, before, after string) ([]*PaymentMethod, bool, error) {
+	var data []*PaymentMethod
+	params := listParams(limit, before, after)
+	if customerID != "" {
+		params.Add("customer", customerID)
+	}
+	params.Add("type", typ)
+	list, err := queryList("/payment_methods", params, &data)
+	return data, list.More, err
+}
+
+// createValues encodes params for creating a PaymentMethod, sending only the
+// fields that are set.
+func (PaymentMethodClient) createValues(params *PaymentMethodParams) url.Values {
+	values := make(url.Values)
+	if params.Type != "" {
+		values.Add("type", params.Type)
+	}
+	if c := params.Card; c != nil {
+		if c.Number != "" {
+			values.Add("card[number]", c.Number)
+		}
+		appendPaymentMethodCardExpiry(values, c)
+		if c.CVC != "" {
+			values.Add("card[cvc]", c.CVC)
+		}
+	}
+	if b := params.USBankAccount; b != nil {
+		if b.AccountNumber != "" {
+			values.Add("us_bank_account[account_number]", b.AccountNumber)
+		}
+		if b.RoutingNumber != "" {
+			values.Add("us_bank_account[routing_number]", b.RoutingNumber)
+		}
+		appendUSBankAccountType(values, b)
+	}
+	if params.IBAN != "" {
+		values.Add("sepa_debit[iban]", params.IBAN)
+	}
+	if b := params.BillingDetails; b != nil {
+		appendBillingDetails(values, b)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// updateValues encodes params for updating a PaymentMethod. The type, card
+// number and CVC, bank account numbers and IBAN cannot be updated, so they
+// are never sent.
+func (PaymentMethodClient) updateValues(params *PaymentMethodParams) url.Values {
+	values := make(url.Values)
+	if c := params.Card; c != nil {
+		appendPaymentMethodCardExpiry(values, c)
+	}
+	if b := params.USBankAccount; b != nil {
+		appendUSBankAccountType(values, b)
+	}
+	if b := params.BillingDetails; b != nil {
+		appendBillingDetails(values, b)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+func appendPaymentMethodCardExpiry(values url.Values, c *CardParams) {
+	if c.ExpMonth != 0 {
+		values.Add("card[exp_month]", strconv.Itoa(c.ExpMonth))
+	}
+	if c.ExpYear != 0 {
+		values.Add("card[exp_year]", strconv.Itoa(c.ExpYear))
+	}
+}
+
+func appendUSBankAccountType(values url.Values, b *USBankAccountParams) {
+	if b.AccountHolderType != "" {
+		values.Add("us_bank_account[account_holder_type]", b.AccountHolderType)
+	}
+	if b.AccountType != "" {
+		values.Add("us_bank_account[account_type]", b.AccountType)
+	}
+}
+
+func appendBillingDetails(values url.Values, b *BillingDetails) {
+	if b.Name != "" {
+		values.Add("billing_details[name]", b.Name)
+	}
+	if b.Email != "" {
+		values.Add("billing_details[email]", b.Email)
+	}
+	if b.Phone != "" {
+		values.Add("billing_details[phone]", b.Phone)
+	}
+	if b.Address != nil {
+		appendAddress(values, "billing_details[address]", b.Address)
+	}
+}
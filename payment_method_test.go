@@ -0,0 +1,107 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreatePaymentMethod will test that a sepa_debit PaymentMethod is
+// created with its billing details, and that only the matching details are
+// decoded.
+func TestCreatePaymentMethod(t *testing.T) {
+	req, done := mockServer(`{
+		"id": "pm_1",
+		"type": "sepa_debit",
+		"billing_details": {"name": "Jenny Rosen", "address": {"country": "DE"}},
+		"sepa_debit": {"bank_code": "37040044", "country": "DE", "last4": "3000"}
+	}`)
+	defer done()
+
+	pm, err := PaymentMethods.Create(&PaymentMethodParams{
+		Type: PaymentMethodSEPADebit,
+		IBAN: "DE89370400440532013000",
+		BillingDetails: &BillingDetails{
+			Name:    "Jenny Rosen",
+			Address: &Address{Country: "DE"},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected PaymentMethod, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"type":                              PaymentMethodSEPADebit,
+		"sepa_debit[iban]":                  "DE89370400440532013000",
+		"billing_details[name]":             "Jenny Rosen",
+		"billing_details[address][country]": "DE",
+	})
+	if pm.SEPADebit == nil || pm.SEPADebit.Last4 != "3000" {
+		t.Errorf("Expected SEPA Debit details, got %+v", pm.SEPADebit)
+	}
+	if pm.Card != nil || pm.USBankAccount != nil {
+		t.Errorf("Expected only SEPA Debit details, got %+v", pm)
+	}
+}
+
+// TestAttachPaymentMethod will test that a PaymentMethod is attached to the
+// given customer.
+func TestAttachPaymentMethod(t *testing.T) {
+	req, done := mockServer(`{"id": "pm_1", "type": "card", "customer": "cus_1", "card": {"brand": "visa", "last4": "4242"}}`)
+	defer done()
+
+	pm, err := PaymentMethods.Attach("pm_1", "cus_1")
+	if err != nil {
+		t.Errorf("Expected PaymentMethod, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/payment_methods/pm_1/attach" || req.Form.Get("customer") != "cus_1" {
+		t.Errorf("Expected attach to cus_1, got %s %v", req.Path, req.Form)
+	}
+	if pm.Customer != "cus_1" || pm.Card == nil || pm.Card.Brand != "visa" {
+		t.Errorf("Expected visa card attached to cus_1, got %+v", pm)
+	}
+}
+
+// TestUpdatePaymentMethod will test that only the fields that can be updated
+// are sent when updating a PaymentMethod.
+func TestUpdatePaymentMethod(t *testing.T) {
+	req, done := mockServer(`{"id": "pm_1", "type": "card", "card": {"exp_month": 12, "exp_year": 2030}}`)
+	defer done()
+
+	_, err := PaymentMethods.Update("pm_1", &PaymentMethodParams{
+		Type:          PaymentMethodCard,
+		Card:          &CardParams{Number: "4242424242424242", ExpMonth: 12, ExpYear: 2030, CVC: "123"},
+		USBankAccount: &USBankAccountParams{AccountType: "savings"},
+	})
+	if err != nil {
+		t.Errorf("Expected PaymentMethod, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"card[exp_month]":               "12",
+		"card[exp_year]":                "2030",
+		"us_bank_account[account_type]": "savings",
+	})
+	for _, k := range []string{"type", "card[number]", "card[cvc]", "us_bank_account[account_number]", "us_bank_account[routing_number]"} {
+		if _, ok := req.Form[k]; ok {
+			t.Errorf("Expected no %s param, got %q", k, req.Form.Get(k))
+		}
+	}
+}
+
+// TestListPaymentMethods will test that the customer is only sent when it is
+// given.
+func TestListPaymentMethods(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "pm_1", "type": "card"}]}`)
+	defer done()
+
+	if _, _, err := PaymentMethods.List("", PaymentMethodCard, 10, "", ""); err != nil {
+		t.Errorf("Expected PaymentMethods, got Error %s", err.Error())
+		return
+	}
+	if _, ok := req.Form["customer"]; ok {
+		t.Errorf("Expected no customer param, got %q", req.Form.Get("customer"))
+	}
+	if got := req.Form.Get("type"); got != PaymentMethodCard {
+		t.Errorf("Expected param type=card, got %q", got)
+	}
+}
@@ -0,0 +1,102 @@
+package stripe
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+// Payment Source Objects
+const (
+	SourceObjectCard        = "card"
+	SourceObjectBankAccount = "bank_account"
+	SourceObjectSource      = "source"
+)
+
+// PaymentSource is a payment source, such as one of a customer's or the
+// source of a charge, which may be a card, a bank account or a Source. Only
+// the field matching the Object is set; none are set for other objects, nor
+// for a source that was not expanded, of which only the ID is known.
+type PaymentSource struct {
+	Object      string
+	Card        *Card
+	BankAccount *BankAccount
+	Source      *Source
+
+	id string // set when not expanded
+}
+
+// Expanded returns whether the source was decoded from an object, rather
+// than from its ID alone.
+func (s *PaymentSource) Expanded() bool {
+	return s.id == ""
+}
+
+// ID returns the ID of the card, bank account or Source.
+func (s *PaymentSource) ID() string {
+	switch {
+	case s.id != "":
+		return s.id
+	case s.Card != nil:
+		return s.Card.ID
+	case s.BankAccount != nil:
+		return s.BankAccount.ID
+	case s.Source != nil:
+		return s.Source.ID
+	}
+	return ""
+}
+
+// IsCard returns whether the source is a card, judging a source that was not
+// expanded by the prefix of its ID.
+func (s *PaymentSource) IsCard() bool {
+	if s.id != "" {
+		return strings.HasPrefix(s.id, "card_")
+	}
+	return s.Card != nil
+}
+
+// UnmarshalJSON decodes a PaymentSource into the type given by its object
+// field, or from its ID alone if it was not expanded.
+func (s *PaymentSource) UnmarshalJSON(data []byte) error {
+	if len(data) > 0 && data[0] == '"' {
+		*s = PaymentSource{}
+		return json.Unmarshal(data, &s.id)
+	}
+	obj := struct {
+		Object string `json:"object"`
+	}{}
+	if err := json.Unmarshal(data, &obj); err != nil {
+		return err
+	}
+	*s = PaymentSource{Object: obj.Object}
+	switch obj.Object {
+	case SourceObjectCard:
+		s.Card = &Card{}
+		return json.Unmarshal(data, s.Card)
+	case SourceObjectBankAccount:
+		s.BankAccount = &BankAccount{}
+		return json.Unmarshal(data, s.BankAccount)
+	case SourceObjectSource:
+		s.Source = &Source{}
+		return json.Unmarshal(data, s.Source)
+	}
+	return nil
+}
+
+// PaymentSourceList is a page of a customer's payment sources.
+type PaymentSourceList struct {
+	ListObject
+	Data []*PaymentSource `json:"data"`
+}
+
+// Len returns the number of sources in this page of the list.
+func (l *PaymentSourceList) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the sources in this page of the list, reporting a warning if
+// the list has more results.
+func (l *PaymentSourceList) Page() []*PaymentSource {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
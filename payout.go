@@ -0,0 +1,157 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Payout Statuses
+const (
+	PayoutPending   = "pending"
+	PayoutInTransit = "in_transit"
+	PayoutPaid      = "paid"
+	PayoutFailed    = "failed"
+	PayoutCanceled  = "canceled"
+)
+
+// Payout Methods
+const (
+	PayoutStandard = "standard"
+	PayoutInstant  = "instant"
+)
+
+// Payout Source Types, the part of the balance a payout is drawn from
+const (
+	PayoutSourceCard        = "card"
+	PayoutSourceBankAccount = "bank_account"
+	PayoutSourceFPX         = "fpx"
+)
+
+// Payout represents funds sent from your Stripe balance to a bank account or
+// debit card.
+//
+// see https://stripe.com/docs/api#payout_object
+type Payout struct {
+	ID                  string            `json:"id"`
+	Amount              int               `json:"amount"`
+	Currency            string            `json:"currency"`
+	ArrivalDate         UnixTime          `json:"arrival_date"`
+	Automatic           bool              `json:"automatic"`
+	BalanceTransaction  string            `json:"balance_transaction,omitempty"`
+	Description         string            `json:"description,omitempty"`
+	Destination         string            `json:"destination,omitempty"`
+	Method              string            `json:"method"`
+	SourceType          string            `json:"source_type"`
+	StatementDescriptor string            `json:"statement_descriptor,omitempty"`
+	Status              string            `json:"status"`
+	Type                string            `json:"type"`
+	FailureCode         string            `json:"failure_code,omitempty"`
+	FailureMessage      string            `json:"failure_message,omitempty"`
+	OriginalPayout      string            `json:"original_payout,omitempty"`
+	ReversedBy          string            `json:"reversed_by,omitempty"`
+	Created             UnixTime          `json:"created"`
+	Livemode            bool              `json:"livemode"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+}
+
+// PayoutParams encapsulates options for creating a Payout.
+type PayoutParams struct {
+	// The amount to pay out, in cents, and its currency.
+	Amount   int
+	Currency string
+
+	// (Optional) The ID of the bank account or card to pay out to. Defaults
+	// to the default external account for the currency.
+	Destination string
+
+	// (Optional) Either PayoutStandard (the default) or PayoutInstant.
+	// Instant payouts arrive within minutes, and can only be sent to
+	// eligible debit cards and bank accounts.
+	Method string
+
+	// (Optional) The part of the balance to draw the payout from, such as
+	// PayoutSourceCard. Defaults to card.
+	SourceType string
+
+	// (Optional) An arbitrary string attached to the Payout.
+	Description string
+
+	// (Optional) The description shown on the bank statement.
+	StatementDescriptor string
+
+	Metadata map[string]string
+}
+
+// PayoutClient encapsulates operations for creating, canceling, reversing
+// and querying payouts using the Stripe REST API.
+type PayoutClient struct{}
+
+// Creates a new Payout.
+//
+// see https://stripe.com/docs/api#create_payout
+func (PayoutClient) Create(params *PayoutParams) (*Payout, error) {
+	values := url.Values{
+		"amount":   {strconv.Itoa(params.Amount)},
+		"currency": {params.Currency},
+	}
+	if params.Destination != "" {
+		values.Add("destination", params.Destination)
+	}
+	if params.Method != "" {
+		values.Add("method", params.Method)
+	}
+	if params.SourceType != "" {
+		values.Add("source_type", params.SourceType)
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.StatementDescriptor != "" {
+		values.Add("statement_descriptor", params.StatementDescriptor)
+	}
+	appendMetadata(values, params.Metadata)
+	res := &Payout{}
+	return res, query("POST", "/payouts", values, res)
+}
+
+// Retrieves the Payout with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_payout
+func (PayoutClient) Get(id string) (*Payout, error) {
+	res := &Payout{}
+	return res, query("GET", "/payouts/"+url.QueryEscape(id), nil, res)
+}
+
+// Cancels the Payout with the given ID, returning its funds to the balance.
+// Only pending payouts can be canceled.
+//
+// see https://stripe.com/docs/api#cancel_payout
+func (PayoutClient) Cancel(id string) (*Payout, error) {
+	res := &Payout{}
+	return res, query("POST", "/payouts/"+url.QueryEscape(id)+"/cancel", nil, res)
+}
+
+// Reverses the paid Payout with the given ID, by sending a new payout in
+// the opposite direction. The reversing payout is returned; its
+// OriginalPayout is the given ID. Only payouts to US bank accounts can be
+// reversed.
+//
+// see https://stripe.com/docs/api#reverse_payout
+func (PayoutClient) Reverse(id string) (*Payout, error) {
+	res := &Payout{}
+	return res, query("POST", "/payouts/"+url.QueryEscape(id)+"/reverse", nil, res)
+}
+
+// Returns a list of your Payouts, optionally only those with the given
+// status, such as PayoutPending.
+//
+// see https://stripe.com/docs/api#list_payouts
+func (PayoutClient) List(status string, limit int, before, after string) ([]*Payout, bool, error) {
+	var data []*Payout
+	params := listParams(limit, before, after)
+	if status != "" {
+		params.Add("status", status)
+	}
+	list, err := queryList("/payouts", params, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,59 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateInstantPayout will test that an instant Payout is created with
+// its method and source type.
+func TestCreateInstantPayout(t *testing.T) {
+	req, done := mockServer(`{"id": "po_1", "amount": 5000, "currency": "usd", "method": "instant", "source_type": "card", "status": "pending"}`)
+	defer done()
+
+	payout, err := Payouts.Create(&PayoutParams{
+		Amount:      5000,
+		Currency:    "usd",
+		Destination: "card_1",
+		Method:      PayoutInstant,
+		SourceType:  PayoutSourceCard,
+	})
+	if err != nil {
+		t.Errorf("Expected Payout, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/payouts" {
+		t.Errorf("Expected POST /v1/payouts, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"amount":      "5000",
+		"currency":    "usd",
+		"destination": "card_1",
+		"method":      "instant",
+		"source_type": "card",
+	})
+	if payout.Method != PayoutInstant || payout.Status != PayoutPending {
+		t.Errorf("Expected pending instant Payout, got %+v", payout)
+	}
+}
+
+// TestCancelAndReversePayout will test that payouts are canceled and
+// reversed through their endpoints.
+func TestCancelAndReversePayout(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"POST /v1/payouts/po_1/cancel":  `{"id": "po_1", "status": "canceled"}`,
+		"POST /v1/payouts/po_2/reverse": `{"id": "po_3", "original_payout": "po_2", "status": "pending"}`,
+	})
+	defer done()
+
+	canceled, err := Payouts.Cancel("po_1")
+	if err != nil || canceled.Status != PayoutCanceled {
+		t.Errorf("Expected canceled Payout, got %+v, %v", canceled, err)
+	}
+	reversal, err := Payouts.Reverse("po_2")
+	if err != nil || reversal.OriginalPayout != "po_2" {
+		t.Errorf("Expected reversal of po_2, got %+v, %v", reversal, err)
+	}
+	if len(*reqs) != 2 {
+		t.Errorf("Expected 2 requests, got %d", len(*reqs))
+	}
+}
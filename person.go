@@ -0,0 +1,202 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Person Verification Statuses
+const (
+	VerificationUnverified = "unverified"
+	VerificationPending    = "pending"
+	VerificationVerified   = "verified"
+)
+
+// Person represents a person associated with a connected account, such as
+// the account representative, an owner or a director.
+//
+// see https://stripe.com/docs/api#person_object
+type Person struct {
+	ID           string             `json:"id"`
+	Account      string             `json:"account"`
+	FirstName    string             `json:"first_name,omitempty"`
+	LastName     string             `json:"last_name,omitempty"`
+	Email        string             `json:"email,omitempty"`
+	Phone        string             `json:"phone,omitempty"`
+	DOB          *DOB               `json:"dob,omitempty"`
+	Address      *Address           `json:"address,omitempty"`
+	IDNumberSet  bool               `json:"id_number_provided"`
+	Relationship PersonRelationship `json:"relationship"`
+	Verification PersonVerification `json:"verification"`
+	Created      UnixTime           `json:"created"`
+	Metadata     map[string]string  `json:"metadata,omitempty"`
+}
+
+// DOB represents a person's date of birth.
+type DOB struct {
+	Day   int `json:"day"`
+	Month int `json:"month"`
+	Year  int `json:"year"`
+}
+
+// PersonRelationship describes how a Person is related to the account.
+type PersonRelationship struct {
+	Director         bool    `json:"director"`
+	Executive        bool    `json:"executive"`
+	Owner            bool    `json:"owner"`
+	Representative   bool    `json:"representative"`
+	PercentOwnership float64 `json:"percent_ownership,omitempty"`
+	Title            string  `json:"title,omitempty"`
+}
+
+// PersonVerification describes the state of a Person's identity
+// verification.
+type PersonVerification struct {
+	Status      string `json:"status"`
+	Details     string `json:"details,omitempty"`
+	DetailsCode string `json:"details_code,omitempty"`
+}
+
+// PersonParams encapsulates options for creating and updating Persons.
+type PersonParams struct {
+	// (Optional) The person's first name.
+	FirstName string
+
+	// (Optional) The person's last name.
+	LastName string
+
+	// (Optional) The person's email address.
+	Email string
+
+	// (Optional) The person's phone number.
+	Phone string
+
+	// (Optional) The person's date of birth.
+	DOB *DOB
+
+	// (Optional) The person's home address.
+	Address *Address
+
+	// (Optional) The person's government issued ID number.
+	IDNumber string
+
+	// (Optional) How the person is related to the account. Only the flags
+	// that are set (non-nil) are sent.
+	Relationship *PersonRelationshipParams
+
+	Metadata map[string]string
+}
+
+// PersonRelationshipParams encapsulates the relationship flags of a Person.
+type PersonRelationshipParams struct {
+	Director         *bool
+	Executive        *bool
+	Owner            *bool
+	Representative   *bool
+	PercentOwnership *float64
+	Title            string
+}
+
+// PersonClient encapsulates operations for creating, updating, deleting and
+// querying the Persons of a connected account using the Stripe REST API.
+type PersonClient struct{}
+
+func (c PersonClient) path(accountID, personID string) string {
+	p := fmt.Sprintf("/accounts/%s/persons", url.QueryEscape(accountID))
+	if personID != "" {
+		p += "/" + url.QueryEscape(personID)
+	}
+	return p
+}
+
+// Creates a new Person on the given account.
+//
+// see https://stripe.com/docs/api#create_person
+func (c PersonClient) Create(accountID string, params *PersonParams) (*Person, error) {
+	res := &Person{}
+	return res, query("POST", c.path(accountID, ""), c.values(params), res)
+}
+
+// Retrieves the Person with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_person
+func (c PersonClient) Get(accountID, personID string) (*Person, error) {
+	res := &Person{}
+	return res, query("GET", c.path(accountID, personID), nil, res)
+}
+
+// Updates the Person with the given ID.
+//
+// see https://stripe.com/docs/api#update_person
+func (c PersonClient) Update(accountID, personID string, params *PersonParams) (*Person, error) {
+	res := &Person{}
+	return res, query("POST", c.path(accountID, personID), c.values(params), res)
+}
+
+// Deletes the Person with the given ID.
+//
+// see https://stripe.com/docs/api#delete_person
+func (c PersonClient) Delete(accountID, personID string) (bool, error) {
+	res := &DeleteResp{}
+	err := query("DELETE", c.path(accountID, personID), nil, res)
+	return res.Deleted, err
+}
+
+// Returns a list of the Persons on the given account.
+//
+// see https://stripe.com/docs/api#list_persons
+func (c PersonClient) List(accountID string, limit int, before, after string) ([]*Person, bool, error) {
+	var data []*Person
+	list, err := queryList(c.path(accountID, ""), listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (c PersonClient) values(params *PersonParams) url.Values {
+	values := make(url.Values)
+	if params.FirstName != "" {
+		values.Add("first_name", params.FirstName)
+	}
+	if params.LastName != "" {
+		values.Add("last_name", params.LastName)
+	}
+	if params.Email != "" {
+		values.Add("email", params.Email)
+	}
+	if params.Phone != "" {
+		values.Add("phone", params.Phone)
+	}
+	if params.DOB != nil {
+		values.Add("dob[day]", strconv.Itoa(params.DOB.Day))
+		values.Add("dob[month]", strconv.Itoa(params.DOB.Month))
+		values.Add("dob[year]", strconv.Itoa(params.DOB.Year))
+	}
+	if params.Address != nil {
+		appendAddress(values, "address", params.Address)
+	}
+	if params.IDNumber != "" {
+		values.Add("id_number", params.IDNumber)
+	}
+	if r := params.Relationship; r != nil {
+		if r.Director != nil {
+			values.Add("relationship[director]", strconv.FormatBool(*r.Director))
+		}
+		if r.Executive != nil {
+			values.Add("relationship[executive]", strconv.FormatBool(*r.Executive))
+		}
+		if r.Owner != nil {
+			values.Add("relationship[owner]", strconv.FormatBool(*r.Owner))
+		}
+		if r.Representative != nil {
+			values.Add("relationship[representative]", strconv.FormatBool(*r.Representative))
+		}
+		if r.PercentOwnership != nil {
+			values.Add("relationship[percent_ownership]", strconv.FormatFloat(*r.PercentOwnership, 'f', -1, 64))
+		}
+		if r.Title != "" {
+			values.Add("relationship[title]", r.Title)
+		}
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
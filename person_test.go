@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreatePerson will test that a Person is created under the given
+// account, that the relationship flags are encoded, and that the verification
+// status is decoded from the response.
+func TestCreatePerson(t *testing.T) {
+	req, done := mockServer(`{
+		"id": "person_1",
+		"account": "acct_1",
+		"first_name": "Jenny",
+		"relationship": {"owner": true, "representative": true, "percent_ownership": 50},
+		"verification": {"status": "pending"}
+	}`)
+	defer done()
+
+	owner, director := true, false
+	percent := 50.0
+	person, err := Persons.Create("acct_1", &PersonParams{
+		FirstName: "Jenny",
+		DOB:       &DOB{Day: 1, Month: 2, Year: 1980},
+		Relationship: &PersonRelationshipParams{
+			Owner:            &owner,
+			Director:         &director,
+			PercentOwnership: &percent,
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Person, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/accounts/acct_1/persons" {
+		t.Errorf("Expected POST /v1/accounts/acct_1/persons, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"first_name":                      "Jenny",
+		"dob[year]":                       "1980",
+		"relationship[owner]":             "true",
+		"relationship[director]":          "false",
+		"relationship[percent_ownership]": "50",
+	})
+	if _, ok := req.Form["relationship[executive]"]; ok {
+		t.Errorf("Expected unset relationship[executive] to be omitted")
+	}
+	if !person.Relationship.Owner || !person.Relationship.Representative {
+		t.Errorf("Expected Person to be an owner and representative, got %+v", person.Relationship)
+	}
+	if person.Verification.Status != VerificationPending {
+		t.Errorf("Expected Verification Status %s, got %s", VerificationPending, person.Verification.Status)
+	}
+}
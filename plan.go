@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"encoding/json"
 	"net/url"
 	"strconv"
 )
@@ -11,6 +12,26 @@ const (
 	IntervalYear  = "year"
 )
 
+// Plan Usage Types
+const (
+	UsageLicensed = "licensed"
+	UsageMetered  = "metered"
+)
+
+// Plan Usage Aggregations
+const (
+	AggregateSum              = "sum"
+	AggregateLastDuringPeriod = "last_during_period"
+	AggregateLastEver         = "last_ever"
+	AggregateMax              = "max"
+)
+
+// Transform Usage Rounding
+const (
+	RoundUp   = "up"
+	RoundDown = "down"
+)
+
 // Plan holds details about pricing information for different products and
 // feature levels on your site. For example, you might have a $10/month plan
 // for basic features and a different $20/month plan for premium features.
@@ -19,15 +40,105 @@ const (
 type Plan struct {
 	ID                   string            `json:"id"`
 	Name                 string            `json:"name"`
+	Product              string            `json:"product,omitempty"`
 	Amount               int               `json:"amount"`
 	Interval             string            `json:"interval"`
 	IntervalCount        int               `json:"interval_count"`
 	Currency             string            `json:"currency"`
 	TrialPeriodDays      int               `json:"trial_period_days"`
 	StatementDescription string            `json:"statement_description,omitempty"`
+	BillingScheme        string            `json:"billing_scheme,omitempty"`
+	Tiers                []*Tier           `json:"tiers,omitempty"`
+	TiersMode            string            `json:"tiers_mode,omitempty"`
+	UsageType            string            `json:"usage_type,omitempty"`
+	AggregateUsage       string            `json:"aggregate_usage,omitempty"`
+	TransformUsage       *TransformUsage   `json:"transform_usage,omitempty"`
 	Livemode             bool              `json:"livemode"`
 	Created              UnixTime          `json:"created"`
 	Metadata             map[string]string `json:"metadata"`
+
+	// The expanded Product, if the plan was retrieved with its product
+	// expanded; otherwise only its ID is known, in Product.
+	ExpandedProduct *Product `json:"-"`
+}
+
+// UnmarshalJSON decodes a Plan, accepting its product either as an ID or as
+// an expanded Product object, whose ID is set in Product.
+func (p *Plan) UnmarshalJSON(data []byte) error {
+	type plan Plan
+	aux := struct {
+		*plan
+		Product json.RawMessage `json:"product"`
+	}{plan: (*plan)(p)}
+	if err := json.Unmarshal(data, &aux); err != nil {
+		return err
+	}
+	p.Product, p.ExpandedProduct = "", nil
+	switch {
+	case len(aux.Product) > 0 && aux.Product[0] == '{':
+		p.ExpandedProduct = &Product{}
+		if err := json.Unmarshal(aux.Product, p.ExpandedProduct); err != nil {
+			return err
+		}
+		p.Product = p.ExpandedProduct.ID
+	case len(aux.Product) > 0 && aux.Product[0] == '"':
+		return json.Unmarshal(aux.Product, &p.Product)
+	}
+	return nil
+}
+
+// TransformUsage divides the usage or quantity of a Plan before it is
+// billed, such as to bill per 1000 units. Round is either RoundUp or
+// RoundDown.
+type TransformUsage struct {
+	DivideBy int    `json:"divide_by"`
+	Round    string `json:"round"`
+}
+
+// amountFor returns the amount, in cents, the plan bills for the given
+// quantity: divided by the TransformUsage if it is set, or priced by the
+// Tiers of a tiered plan.
+func (p *Plan) amountFor(quantity int) int {
+	if p.BillingScheme != BillingTiered {
+		if t := p.TransformUsage; t != nil && t.DivideBy > 0 {
+			if t.Round == RoundUp {
+				quantity += t.DivideBy - 1
+			}
+			quantity /= t.DivideBy
+		}
+		return p.Amount * quantity
+	}
+
+	amount, prev := 0, 0
+	for _, tier := range p.Tiers {
+		if p.TiersMode == TiersVolume {
+			if tier.UpTo == nil || quantity <= *tier.UpTo {
+				return quantity*tier.UnitAmount + tier.FlatAmount
+			}
+			continue
+		}
+		// Graduated: bill the units that fall within each tier reached.
+		if quantity <= prev {
+			break
+		}
+		units := quantity - prev
+		if tier.UpTo != nil && *tier.UpTo < quantity {
+			units = *tier.UpTo - prev
+			prev = *tier.UpTo
+		} else {
+			prev = quantity
+		}
+		amount += units*tier.UnitAmount + tier.FlatAmount
+	}
+	return amount
+}
+
+// PlanProductParams identifies the product a Plan prices, either by the ID of
+// an existing Product, or by the Name of a new product created with the plan.
+// One of the two is required.
+type PlanProductParams struct {
+	ID   string
+	Name string
 }
 
 // PlanClient encapsulates operations for creating, updating, deleting and
@@ -41,7 +152,7 @@ type PlanParams struct {
 	ID string
 
 	// A positive integer in cents (or 0 for a free plan) representing how much
-	// to charge (on a recurring basis)
+	// to charge (on a recurring basis). Not used for tiered plans.
 	Amount int
 
 	// 3-letter ISO code for currency. Currently, only 'usd' is supported.
@@ -54,19 +165,45 @@ type PlanParams struct {
 	IntervalCount int
 
 	// Name of the plan, to be displayed on invoices and in the web interface.
+	// Plans with a Product may leave it empty to use the product's name.
 	Name string
 
+	// (Optional) The product the plan prices. Only used when creating.
+	Product *PlanProductParams
+
 	// (Optional) Specifies a trial period in (an integer number of) days. If
 	// you include a trial period, the customer won't be billed for the first
 	// time until the trial period ends. If the customer cancels before the
 	// trial period is over, she'll never be billed at all.
-	TrialPeriodDays int
+	TrialPeriodDays *int
 
 	// An arbitrary string to be displayed on your customers' credit card
 	// statements (alongside your company name) for charges created by this
 	// plan.
 	StatementDescription *string
 
+	// (Optional) Either BillingPerUnit (the default) or BillingTiered.
+	BillingScheme string
+
+	// (Optional) Either TiersGraduated or TiersVolume, for tiered plans.
+	TiersMode string
+
+	// (Optional) The tiers of a tiered plan. Leave the last tier's UpTo nil
+	// to make it unbounded.
+	Tiers []*Tier
+
+	// (Optional) Either UsageLicensed (the default), billing for the
+	// quantity subscribed, or UsageMetered, billing for reported usage.
+	UsageType string
+
+	// (Optional) How the usage reported for a metered plan during a period
+	// is billed, such as AggregateSum (the default) or AggregateMax.
+	AggregateUsage string
+
+	// (Optional) Divides the usage or quantity before it is billed. Round
+	// is required, and it cannot be used with tiers.
+	TransformUsage *TransformUsage
+
 	Metadata map[string]string
 }
 
@@ -75,17 +212,41 @@ type PlanParams struct {
 // see https://stripe.com/docs/api#create_plan
 func (PlanClient) Create(params *PlanParams) (*Plan, error) {
 	plan := Plan{}
+	if p := params.Product; p != nil && p.ID == "" && p.Name == "" {
+		return &plan, &ParamError{"product", "either an ID or a name is required"}
+	}
+	if t := params.TransformUsage; t != nil {
+		if t.Round == "" {
+			return &plan, &ParamError{"transform_usage[round]", "is required with transform_usage"}
+		}
+		if params.BillingScheme == BillingTiered || len(params.Tiers) != 0 {
+			return &plan, &ParamError{"transform_usage", "cannot be used with tiers"}
+		}
+	}
 	values := url.Values{
 		"id":       {params.ID},
-		"name":     {params.Name},
-		"amount":   {strconv.Itoa(params.Amount)},
 		"interval": {params.Interval},
 		"currency": {params.Currency},
 	}
+	if params.Name != "" {
+		values.Add("name", params.Name)
+	}
+	if p := params.Product; p != nil {
+		if p.ID != "" {
+			values.Add("product", p.ID)
+		} else {
+			values.Add("product[name]", p.Name)
+		}
+	}
+
+	// tiered plans are priced by their tiers rather than an amount
+	if params.BillingScheme != BillingTiered {
+		values.Add("amount", strconv.Itoa(params.Amount))
+	}
 
 	// trial_period_days is optional, add if specified
-	if params.TrialPeriodDays != 0 {
-		values.Add("trial_period_days", strconv.Itoa(params.TrialPeriodDays))
+	if params.TrialPeriodDays != nil {
+		values.Add("trial_period_days", strconv.Itoa(*params.TrialPeriodDays))
 	}
 	if params.IntervalCount > 1 {
 		values.Add("interval_count", strconv.Itoa(params.IntervalCount))
@@ -93,6 +254,23 @@ func (PlanClient) Create(params *PlanParams) (*Plan, error) {
 	if params.StatementDescription != nil {
 		values.Add("statement_description", *params.StatementDescription)
 	}
+	if params.BillingScheme != "" {
+		values.Add("billing_scheme", params.BillingScheme)
+	}
+	if params.TiersMode != "" {
+		values.Add("tiers_mode", params.TiersMode)
+	}
+	appendTiers(values, "tiers", params.Tiers)
+	if params.UsageType != "" {
+		values.Add("usage_type", params.UsageType)
+	}
+	if params.AggregateUsage != "" {
+		values.Add("aggregate_usage", params.AggregateUsage)
+	}
+	if t := params.TransformUsage; t != nil {
+		values.Add("transform_usage[divide_by]", strconv.Itoa(t.DivideBy))
+		values.Add("transform_usage[round]", t.Round)
+	}
 	appendMetadata(values, params.Metadata)
 
 	err := query("POST", "/plans", values, &plan)
@@ -145,10 +323,7 @@ func (PlanClient) Delete(id string) (bool, error) {
 //
 // see https://stripe.com/docs/api#list_Plans
 func (PlanClient) List(limit int, before, after string) ([]*Plan, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Plan
-	}{}
-	err := query("GET", "/plans", listParams(limit, before, after), &res)
-	return res.Data, res.More, err
+	var data []*Plan
+	list, err := queryList("/plans", listParams(limit, before, after), &data)
+	return data, list.More, err
 }
@@ -1,6 +1,7 @@
 package stripe
 
 import (
+	"encoding/json"
 	"strings"
 	"testing"
 )
@@ -31,7 +32,7 @@ var (
 		Amount:          9,
 		Currency:        USD,
 		Interval:        IntervalMonth,
-		TrialPeriodDays: 365,
+		TrialPeriodDays: Int(365),
 	}
 )
 
@@ -83,6 +84,198 @@ func TestCreatePlan(t *testing.T) {
 	}
 }
 
+// TestCreateTieredPlan will test that a tiered Plan is created with its
+// tiers, and without an amount, and that the tiers are parsed from the
+// response.
+func TestCreateTieredPlan(t *testing.T) {
+	req, done := mockServer(`{"id": "seats", "name": "Seats", "amount": 0, "interval": "month", "currency": "usd",
+		"billing_scheme": "tiered", "tiers_mode": "graduated", "usage_type": "licensed",
+		"tiers": [{"up_to": 10, "unit_amount": 500, "flat_amount": 0}, {"up_to": null, "unit_amount": 400, "flat_amount": 0}]}`)
+	defer done()
+
+	plan, err := Plans.Create(&PlanParams{
+		ID:            "seats",
+		Name:          "Seats",
+		Currency:      USD,
+		Interval:      IntervalMonth,
+		BillingScheme: BillingTiered,
+		TiersMode:     TiersGraduated,
+		Tiers:         []*Tier{{UpTo: Int(10), UnitAmount: 500}, {UnitAmount: 400}},
+		UsageType:     UsageLicensed,
+	})
+	if err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"billing_scheme":        "tiered",
+		"tiers_mode":            "graduated",
+		"usage_type":            "licensed",
+		"tiers[0][up_to]":       "10",
+		"tiers[0][unit_amount]": "500",
+		"tiers[1][up_to]":       "inf",
+		"tiers[1][unit_amount]": "400",
+	})
+	if _, ok := req.Form["amount"]; ok {
+		t.Errorf("Expected no amount param for a tiered plan, got %q", req.Form.Get("amount"))
+	}
+	if plan.BillingScheme != BillingTiered || len(plan.Tiers) != 2 || plan.Tiers[1].UpTo != nil {
+		t.Errorf("Expected tiered Plan with an unbounded last tier, got %+v", plan)
+	}
+}
+
+// TestCreatePlanFreeTier will test that a tier with no amounts, such as the
+// first tier of a graduated plan, is sent with a zero unit amount.
+func TestCreatePlanFreeTier(t *testing.T) {
+	req, done := mockServer(`{"id": "seats", "interval": "month", "currency": "usd", "billing_scheme": "tiered"}`)
+	defer done()
+
+	n := 3
+	_, err := Plans.Create(&PlanParams{
+		ID:            "seats",
+		Name:          "Seats",
+		Currency:      USD,
+		Interval:      IntervalMonth,
+		BillingScheme: BillingTiered,
+		TiersMode:     TiersGraduated,
+		Tiers:         []*Tier{{UpTo: &n}, {UnitAmount: 400}},
+	})
+	if err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"tiers[0][up_to]":       "3",
+		"tiers[0][unit_amount]": "0",
+		"tiers[1][up_to]":       "inf",
+		"tiers[1][unit_amount]": "400",
+	})
+}
+
+// TestCreateMeteredPlan will test that a metered Plan is created with its
+// usage aggregation and transformation, and that both are parsed from the
+// response.
+func TestCreateMeteredPlan(t *testing.T) {
+	req, done := mockServer(`{"id": "api", "name": "API Calls", "amount": 5, "interval": "month", "currency": "usd",
+		"usage_type": "metered", "aggregate_usage": "max", "transform_usage": {"divide_by": 1000, "round": "up"}}`)
+	defer done()
+
+	plan, err := Plans.Create(&PlanParams{
+		ID:             "api",
+		Name:           "API Calls",
+		Amount:         5,
+		Currency:       USD,
+		Interval:       IntervalMonth,
+		UsageType:      UsageMetered,
+		AggregateUsage: AggregateMax,
+		TransformUsage: &TransformUsage{DivideBy: 1000, Round: RoundUp},
+	})
+	if err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"amount":                     "5",
+		"usage_type":                 "metered",
+		"aggregate_usage":            "max",
+		"transform_usage[divide_by]": "1000",
+		"transform_usage[round]":     "up",
+	})
+	if plan.AggregateUsage != AggregateMax || plan.TransformUsage == nil || plan.TransformUsage.DivideBy != 1000 {
+		t.Errorf("Expected metered Plan usage settings, got %+v", plan)
+	}
+
+	for _, test := range []struct {
+		Params PlanParams
+		Param  string
+	}{
+		{PlanParams{ID: "api", TransformUsage: &TransformUsage{DivideBy: 1000}}, "transform_usage[round]"},
+		{PlanParams{ID: "api", BillingScheme: BillingTiered, TransformUsage: &TransformUsage{DivideBy: 1000, Round: RoundUp}}, "transform_usage"},
+	} {
+		_, err := Plans.Create(&test.Params)
+		if perr, ok := err.(*ParamError); !ok || perr.Param != test.Param {
+			t.Errorf("Expected %s ParamError, got %v", test.Param, err)
+		}
+	}
+}
+
+// TestCreatePlanForProduct will test that a Plan is linked to either an
+// existing product, by ID, or to a new product created with it, by name.
+func TestCreatePlanForProduct(t *testing.T) {
+	req, done := mockServer(`{"id": "gold", "amount": 2000, "interval": "month", "currency": "usd", "product": "prod_1"}`)
+	defer done()
+
+	plan, err := Plans.Create(&PlanParams{
+		ID:       "gold",
+		Amount:   2000,
+		Currency: USD,
+		Interval: IntervalMonth,
+		Product:  &PlanProductParams{ID: "prod_1"},
+	})
+	if err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("product"); got != "prod_1" {
+		t.Errorf("Expected param product=prod_1, got %q", got)
+	}
+	if _, ok := req.Form["name"]; ok {
+		t.Errorf("Expected no name param, got %q", req.Form.Get("name"))
+	}
+	if plan.Product != "prod_1" {
+		t.Errorf("Expected Plan Product prod_1, got %q", plan.Product)
+	}
+
+	if _, err := Plans.Create(&PlanParams{
+		ID:       "gold",
+		Amount:   2000,
+		Currency: USD,
+		Interval: IntervalMonth,
+		Product:  &PlanProductParams{Name: "Gold Support"},
+	}); err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("product[name]"); got != "Gold Support" {
+		t.Errorf("Expected param product[name]=Gold Support, got %q", got)
+	}
+	if _, ok := req.Form["product"]; ok {
+		t.Errorf("Expected no product ID param, got %q", req.Form.Get("product"))
+	}
+
+	_, err = Plans.Create(&PlanParams{
+		ID:       "gold",
+		Amount:   2000,
+		Currency: USD,
+		Interval: IntervalMonth,
+		Product:  &PlanProductParams{},
+	})
+	if perr, ok := err.(*ParamError); !ok || perr.Param != "product" {
+		t.Errorf("Expected product ParamError, got %v", err)
+	}
+}
+
+// TestPlanExpandedProduct will test that a Plan's product is decoded from
+// either its ID or an expanded Product.
+func TestPlanExpandedProduct(t *testing.T) {
+	plan := Plan{}
+	if err := json.Unmarshal([]byte(`{"id": "gold", "product": "prod_1"}`), &plan); err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	if plan.Product != "prod_1" || plan.ExpandedProduct != nil {
+		t.Errorf("Expected Plan Product prod_1 not expanded, got %q %+v", plan.Product, plan.ExpandedProduct)
+	}
+
+	if err := json.Unmarshal([]byte(`{"id": "gold", "product": {"id": "prod_2", "name": "Gold Support"}}`), &plan); err != nil {
+		t.Errorf("Expected Plan, got Error %s", err.Error())
+		return
+	}
+	if plan.Product != "prod_2" || plan.ExpandedProduct == nil || plan.ExpandedProduct.Name != "Gold Support" {
+		t.Errorf("Expected expanded Plan Product prod_2, got %q %+v", plan.Product, plan.ExpandedProduct)
+	}
+}
+
 // TestRetrievePlan will test that we can successfully Retrieve a Plan,
 // parse the JSON response, and that all values are populated as expected.
 func TestRetrievePlan(t *testing.T) {
@@ -107,9 +300,9 @@ func TestRetrievePlan(t *testing.T) {
 	if plan.Currency != p2.Currency {
 		t.Errorf("Expected Plan Currency %v, got %v", p2.Currency, plan.Currency)
 	}
-	if plan.TrialPeriodDays != p2.TrialPeriodDays {
+	if plan.TrialPeriodDays != *p2.TrialPeriodDays {
 		t.Errorf("Expected Plan Trial Period %v, got %v",
-			p2.TrialPeriodDays, plan.TrialPeriodDays)
+			*p2.TrialPeriodDays, plan.TrialPeriodDays)
 	}
 }
 
@@ -0,0 +1,295 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Price Types
+const (
+	PriceTypeOneTime   = "one_time"
+	PriceTypeRecurring = "recurring"
+)
+
+// Billing Schemes
+const (
+	BillingPerUnit = "per_unit"
+	BillingTiered  = "tiered"
+)
+
+// Tiers Modes
+const (
+	TiersGraduated = "graduated"
+	TiersVolume    = "volume"
+)
+
+// Price represents how much a Product costs, either once or on a recurring
+// basis. Prices supersede Plans.
+//
+// see https://stripe.com/docs/api#price_object
+type Price struct {
+	ID              string                          `json:"id"`
+	Active          bool                            `json:"active"`
+	Currency        string                          `json:"currency"`
+	Nickname        string                          `json:"nickname,omitempty"`
+	Product         string                          `json:"product"`
+	Type            string                          `json:"type"`
+	UnitAmount      int                             `json:"unit_amount"`
+	BillingScheme   string                          `json:"billing_scheme"`
+	Recurring       *PriceRecurring                 `json:"recurring,omitempty"`
+	Tiers           []*Tier                         `json:"tiers,omitempty"`
+	TiersMode       string                          `json:"tiers_mode,omitempty"`
+	LookupKey       string                          `json:"lookup_key,omitempty"`
+	CurrencyOptions map[string]*PriceCurrencyOption `json:"currency_options,omitempty"`
+	Created         UnixTime                        `json:"created"`
+	Livemode        bool                            `json:"livemode"`
+	Metadata        map[string]string               `json:"metadata,omitempty"`
+}
+
+// PriceRecurring describes the billing interval of a recurring Price.
+type PriceRecurring struct {
+	Interval      string `json:"interval"`
+	IntervalCount int    `json:"interval_count"`
+	UsageType     string `json:"usage_type"`
+}
+
+// Tier is one tier of a tiered Price or Plan. UpTo is nil for the last tier,
+// which has no upper bound.
+type Tier struct {
+	UpTo       *int `json:"up_to"`
+	UnitAmount int  `json:"unit_amount"`
+	FlatAmount int  `json:"flat_amount"`
+}
+
+// PriceCurrencyOption holds the amount of a Price in an additional currency.
+// Tiered prices set the Tiers rather than the UnitAmount.
+type PriceCurrencyOption struct {
+	UnitAmount int     `json:"unit_amount"`
+	Tiers      []*Tier `json:"tiers,omitempty"`
+}
+
+// PriceParams encapsulates options for creating and updating Prices.
+type PriceParams struct {
+	// 3-letter ISO code for currency. Required when creating.
+	Currency string
+
+	// The ID of the product the price is for. Required when creating.
+	Product string
+
+	// (Optional) The amount in cents to charge per unit. Required for
+	// per_unit prices.
+	UnitAmount *int
+
+	// (Optional) The billing interval, for recurring prices. One-time prices
+	// are created when left nil.
+	Recurring *PriceRecurring
+
+	// (Optional) Either per_unit (the default) or tiered.
+	BillingScheme string
+
+	// (Optional) Either graduated or volume, for tiered prices.
+	TiersMode string
+
+	// (Optional) The tiers of a tiered price. Leave the last tier's UpTo nil
+	// to make it unbounded.
+	Tiers []*Tier
+
+	// (Optional) A brief description of the price, hidden from customers.
+	Nickname string
+
+	// (Optional) Whether the price can be used for new purchases.
+	Active *bool
+
+	// (Optional) A lookup key used to retrieve prices dynamically.
+	LookupKey string
+
+	// (Optional) Move the lookup key from any price that already has it.
+	TransferLookupKey bool
+
+	// (Optional) The unit amount in other currencies, keyed by currency.
+	CurrencyOptions map[string]*PriceCurrencyOption
+
+	Metadata map[string]string
+}
+
+// PriceListParams encapsulates options for listing Prices.
+type PriceListParams struct {
+	// (Optional) Only return active or inactive prices.
+	Active *bool
+
+	// (Optional) Only return prices for the given product.
+	Product string
+
+	// (Optional) Only return one_time or recurring prices.
+	Type string
+
+	// (Optional) Only return prices with the given currency.
+	Currency string
+
+	// (Optional) Only return prices with one of the given lookup keys.
+	LookupKeys []string
+
+	// (Optional) Only return prices created within the given range.
+	Created *DateFilter
+
+	// (Optional) The page size and cursors, as for other lists.
+	Limit  int
+	Before string
+	After  string
+}
+
+// PriceClient encapsulates operations for creating, updating and querying
+// prices using the Stripe REST API.
+type PriceClient struct{}
+
+// Creates a new Price.
+//
+// see https://stripe.com/docs/api#create_price
+func (c PriceClient) Create(params *PriceParams) (*Price, error) {
+	res := &Price{}
+	return res, query("POST", "/prices", c.values(params), res)
+}
+
+// Retrieves the Price with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_price
+func (PriceClient) Get(id string) (*Price, error) {
+	res := &Price{}
+	return res, query("GET", "/prices/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Price with the given ID. Only the active flag, nickname, lookup
+// key, currency options and metadata of a price can be changed.
+//
+// see https://stripe.com/docs/api#update_price
+func (c PriceClient) Update(id string, params *PriceParams) (*Price, error) {
+	res := &Price{}
+	return res, query("POST", "/prices/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Returns a list of your Prices matching the given filters, or the most
+// recent Prices if params is nil.
+//
+// see https://stripe.com/docs/api#list_prices
+func (PriceClient) List(params *PriceListParams) ([]*Price, bool, error) {
+	if params == nil {
+		params = &PriceListParams{}
+	}
+	var data []*Price
+	values := listParams(params.Limit, params.Before, params.After)
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	if params.Product != "" {
+		values.Add("product", params.Product)
+	}
+	if params.Type != "" {
+		values.Add("type", params.Type)
+	}
+	if params.Currency != "" {
+		values.Add("currency", params.Currency)
+	}
+	for _, key := range params.LookupKeys {
+		values.Add("lookup_keys[]", key)
+	}
+	if params.Created != nil {
+		params.Created.appendValues(values, "created")
+	}
+	list, err := queryList("/prices", values, &data)
+	return data, list.More, err
+}
+
+// Searches your Prices using Stripe's search query language, e.g.
+// "active:'true' AND metadata['order_id']:'6735'". It returns a page of
+// results, and the page token to pass to get the next page, which is empty
+// when there are no more results.
+//
+// see https://stripe.com/docs/api#search_prices
+func (PriceClient) Search(q string, limit int, page string) ([]*Price, string, error) {
+	values := url.Values{"query": {q}}
+	if limit > 0 {
+		values.Add("limit", strconv.Itoa(limit))
+	}
+	if page != "" {
+		values.Add("page", page)
+	}
+	res := struct {
+		Data     []*Price `json:"data"`
+		More     bool     `json:"has_more"`
+		NextPage string   `json:"next_page"`
+	}{}
+	err := query("GET", "/prices/search", values, &res)
+	if !res.More {
+		res.NextPage = ""
+	}
+	return res.Data, res.NextPage, err
+}
+
+func (PriceClient) values(params *PriceParams) url.Values {
+	values := make(url.Values)
+	if params.Currency != "" {
+		values.Add("currency", params.Currency)
+	}
+	if params.Product != "" {
+		values.Add("product", params.Product)
+	}
+	if params.UnitAmount != nil {
+		values.Add("unit_amount", strconv.Itoa(*params.UnitAmount))
+	}
+	if r := params.Recurring; r != nil {
+		values.Add("recurring[interval]", r.Interval)
+		if r.IntervalCount > 1 {
+			values.Add("recurring[interval_count]", strconv.Itoa(r.IntervalCount))
+		}
+		if r.UsageType != "" {
+			values.Add("recurring[usage_type]", r.UsageType)
+		}
+	}
+	if params.BillingScheme != "" {
+		values.Add("billing_scheme", params.BillingScheme)
+	}
+	if params.TiersMode != "" {
+		values.Add("tiers_mode", params.TiersMode)
+	}
+	appendTiers(values, "tiers", params.Tiers)
+	if params.Nickname != "" {
+		values.Add("nickname", params.Nickname)
+	}
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	if params.LookupKey != "" {
+		values.Add("lookup_key", params.LookupKey)
+	}
+	if params.TransferLookupKey {
+		values.Add("transfer_lookup_key", "true")
+	}
+	for currency, opt := range params.CurrencyOptions {
+		key := fmt.Sprintf("currency_options[%s]", currency)
+		if len(opt.Tiers) == 0 {
+			values.Add(key+"[unit_amount]", strconv.Itoa(opt.UnitAmount))
+		}
+		appendTiers(values, key+"[tiers]", opt.Tiers)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// appendTiers adds the tiers to values as an indexed array under the given
+// key (e.g. tiers[0][up_to]). A tier with no upper bound is sent as "inf".
+// The unit amount is always sent, so that free tiers are accepted.
+func appendTiers(values url.Values, key string, tiers []*Tier) {
+	for i, tier := range tiers {
+		p := fmt.Sprintf("%s[%d]", key, i)
+		if tier.UpTo != nil {
+			values.Add(p+"[up_to]", strconv.Itoa(*tier.UpTo))
+		} else {
+			values.Add(p+"[up_to]", "inf")
+		}
+		values.Add(p+"[unit_amount]", strconv.Itoa(tier.UnitAmount))
+		if tier.FlatAmount != 0 {
+			values.Add(p+"[flat_amount]", strconv.Itoa(tier.FlatAmount))
+		}
+	}
+}
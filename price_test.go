@@ -0,0 +1,129 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestCreateTieredPrice will test that a recurring tiered Price is encoded
+// with its tiers and currency options, including the unbounded last tier.
+func TestCreateTieredPrice(t *testing.T) {
+	req, done := mockServer(`{"id": "price_1", "type": "recurring", "billing_scheme": "tiered", "tiers_mode": "graduated", "lookup_key": "seats"}`)
+	defer done()
+
+	_, err := Prices.Create(&PriceParams{
+		Currency:      USD,
+		Product:       "prod_1",
+		Recurring:     &PriceRecurring{Interval: IntervalMonth},
+		BillingScheme: BillingTiered,
+		TiersMode:     TiersGraduated,
+		Tiers: []*Tier{
+			{UpTo: Int(10), UnitAmount: 500},
+			{UnitAmount: 400, FlatAmount: 1000},
+		},
+		LookupKey:       "seats",
+		CurrencyOptions: map[string]*PriceCurrencyOption{EUR: {UnitAmount: 450}},
+	})
+	if err != nil {
+		t.Errorf("Expected Price, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"recurring[interval]":                IntervalMonth,
+		"billing_scheme":                     BillingTiered,
+		"tiers_mode":                         TiersGraduated,
+		"tiers[0][up_to]":                    "10",
+		"tiers[0][unit_amount]":              "500",
+		"tiers[1][up_to]":                    "inf",
+		"tiers[1][flat_amount]":              "1000",
+		"lookup_key":                         "seats",
+		"currency_options[eur][unit_amount]": "450",
+	})
+}
+
+// TestCreatePriceFreeTier will test that a tier with no amounts is sent with
+// a zero unit amount, and that tiered currency options are sent without one.
+func TestCreatePriceFreeTier(t *testing.T) {
+	req, done := mockServer(`{"id": "price_1", "billing_scheme": "tiered"}`)
+	defer done()
+
+	_, err := Prices.Create(&PriceParams{
+		Currency:      USD,
+		Product:       "prod_1",
+		Recurring:     &PriceRecurring{Interval: IntervalMonth},
+		BillingScheme: BillingTiered,
+		TiersMode:     TiersGraduated,
+		Tiers:         []*Tier{{UpTo: Int(5)}, {UnitAmount: 400}},
+		CurrencyOptions: map[string]*PriceCurrencyOption{
+			EUR: {Tiers: []*Tier{{UpTo: Int(5)}, {UnitAmount: 350}}},
+		},
+	})
+	if err != nil {
+		t.Errorf("Expected Price, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"tiers[0][up_to]":                              "5",
+		"tiers[0][unit_amount]":                        "0",
+		"currency_options[eur][tiers][0][unit_amount]": "0",
+		"currency_options[eur][tiers][1][unit_amount]": "350",
+	})
+	if _, ok := req.Form["currency_options[eur][unit_amount]"]; ok {
+		t.Errorf("Expected no unit_amount for tiered currency option, got %q", req.Form.Get("currency_options[eur][unit_amount]"))
+	}
+}
+
+// TestDecodePriceTiers will test that the unbounded last tier of a Price is
+// decoded with a nil UpTo.
+func TestDecodePriceTiers(t *testing.T) {
+	price := Price{}
+	json.Unmarshal([]byte(`{"id": "price_1", "tiers": [{"up_to": 10, "unit_amount": 500}, {"up_to": null, "unit_amount": 400}]}`), &price)
+	if len(price.Tiers) != 2 {
+		t.Errorf("Expected 2 Tiers, got %d", len(price.Tiers))
+		return
+	}
+	if price.Tiers[0].UpTo == nil || *price.Tiers[0].UpTo != 10 {
+		t.Errorf("Expected first Tier up to 10, got %v", price.Tiers[0].UpTo)
+	}
+	if price.Tiers[1].UpTo != nil {
+		t.Errorf("Expected last Tier to be unbounded, got %d", *price.Tiers[1].UpTo)
+	}
+}
+
+// TestSearchPrices will test that the next page token is only returned when
+// there are more results.
+func TestSearchPrices(t *testing.T) {
+	req, done := mockServer(`{"object": "search_result", "has_more": true, "next_page": "page_2", "data": [{"id": "price_1"}]}`)
+	defer done()
+
+	prices, next, err := Prices.Search("lookup_key:'seats'", 1, "")
+	if err != nil {
+		t.Errorf("Expected Prices, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/prices/search" || req.Form.Get("query") != "lookup_key:'seats'" {
+		t.Errorf("Expected search query, got %s %v", req.Path, req.Form)
+	}
+	if len(prices) != 1 || next != "page_2" {
+		t.Errorf("Expected 1 Price and next page page_2, got %d and %q", len(prices), next)
+	}
+}
+
+// TestListPricesNilParams will test that listing prices without params
+// sends no filters.
+func TestListPricesNilParams(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "price_1"}]}`)
+	defer done()
+
+	prices, _, err := Prices.List(nil)
+	if err != nil {
+		t.Errorf("Expected Prices, got Error %s", err.Error())
+		return
+	}
+	if len(req.Form) != 0 {
+		t.Errorf("Expected no params, got %v", req.Form)
+	}
+	if len(prices) != 1 {
+		t.Errorf("Expected 1 Price, got %d", len(prices))
+	}
+}
@@ -0,0 +1,141 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Product represents a good or service you sell. Prices and plans describe
+// how much the product costs.
+//
+// see https://stripe.com/docs/api#product_object
+type Product struct {
+	ID          string            `json:"id"`
+	Name        string            `json:"name"`
+	Description string            `json:"description,omitempty"`
+	Active      bool              `json:"active"`
+	Images      []string          `json:"images"`
+	Created     UnixTime          `json:"created"`
+	Updated     UnixTime          `json:"updated"`
+	Livemode    bool              `json:"livemode"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// ProductParams encapsulates options for creating and updating Products.
+type ProductParams struct {
+	// (Optional) Unique string of your choice that will be used to identify
+	// this product. Only used when creating.
+	ID string
+
+	// The product's name, meant to be displayable to the customer. Required
+	// when creating.
+	Name string
+
+	// (Optional) The product's description, meant to be displayable to the
+	// customer.
+	Description string
+
+	// (Optional) Whether the product is available for purchase.
+	Active *bool
+
+	// (Optional) A list of up to 8 URLs of images for this product.
+	Images []string
+
+	Metadata map[string]string
+}
+
+// ProductListParams encapsulates options for listing Products.
+type ProductListParams struct {
+	// (Optional) Only return products that are active or inactive.
+	Active *bool
+
+	// (Optional) Only return products created within the given range.
+	Created *DateFilter
+
+	// (Optional) The page size and cursors, as for other lists.
+	Limit  int
+	Before string
+	After  string
+}
+
+// ProductClient encapsulates operations for creating, updating, deleting and
+// querying products using the Stripe REST API.
+type ProductClient struct{}
+
+// Creates a new Product.
+//
+// see https://stripe.com/docs/api#create_product
+func (c ProductClient) Create(params *ProductParams) (*Product, error) {
+	values := c.values(params)
+	if params.ID != "" {
+		values.Add("id", params.ID)
+	}
+	res := &Product{}
+	return res, query("POST", "/products", values, res)
+}
+
+// Retrieves the Product with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_product
+func (ProductClient) Get(id string) (*Product, error) {
+	res := &Product{}
+	return res, query("GET", "/products/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Product with the given ID.
+//
+// see https://stripe.com/docs/api#update_product
+func (c ProductClient) Update(id string, params *ProductParams) (*Product, error) {
+	res := &Product{}
+	return res, query("POST", "/products/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Deletes the Product with the given ID. Products with prices or plans cannot
+// be deleted, but can be made inactive.
+//
+// see https://stripe.com/docs/api#delete_product
+func (ProductClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/products/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Products matching the given filters, or the most
+// recent Products if params is nil.
+//
+// see https://stripe.com/docs/api#list_products
+func (ProductClient) List(params *ProductListParams) ([]*Product, bool, error) {
+	if params == nil {
+		params = &ProductListParams{}
+	}
+	var data []*Product
+	values := listParams(params.Limit, params.Before, params.After)
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	if params.Created != nil {
+		params.Created.appendValues(values, "created")
+	}
+	list, err := queryList("/products", values, &data)
+	return data, list.More, err
+}
+
+func (ProductClient) values(params *ProductParams) url.Values {
+	values := make(url.Values)
+	if params.Name != "" {
+		values.Add("name", params.Name)
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	for _, image := range params.Images {
+		values.Add("images[]", image)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
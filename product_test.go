@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestListProducts will test that the active and created filters are sent
+// when listing Products.
+func TestListProducts(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "prod_1", "name": "Calzone", "active": true, "images": ["https://example.com/calzone.png"]}]}`)
+	defer done()
+
+	start := time.Unix(1500000000, 0)
+	products, _, err := Products.List(&ProductListParams{
+		Active:  Bool(true),
+		Created: &DateFilter{Gte: &UnixTime{start}},
+		Limit:   10,
+	})
+	if err != nil {
+		t.Errorf("Expected Product List, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"active":       "true",
+		"created[gte]": "1500000000",
+		"limit":        "10",
+	})
+	if _, ok := req.Form["created[lte]"]; ok {
+		t.Errorf("Expected created[lte] to be omitted")
+	}
+	if len(products) != 1 || products[0].Name != "Calzone" || len(products[0].Images) != 1 {
+		t.Errorf("Expected Product Calzone with an image, got %v", products)
+	}
+}
+
+// TestListProductsNilParams will test that listing products without params
+// sends no filters.
+func TestListProductsNilParams(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "prod_1"}]}`)
+	defer done()
+
+	products, _, err := Products.List(nil)
+	if err != nil {
+		t.Errorf("Expected Products, got Error %s", err.Error())
+		return
+	}
+	if len(req.Form) != 0 {
+		t.Errorf("Expected no params, got %v", req.Form)
+	}
+	if len(products) != 1 {
+		t.Errorf("Expected 1 Product, got %d", len(products))
+	}
+}
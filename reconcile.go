@@ -0,0 +1,109 @@
+package stripe
+
+import (
+	"time"
+)
+
+// ExpectedRenewal is a subscription renewal that an application expects
+// Stripe to bill.
+type ExpectedRenewal struct {
+	Customer     string
+	Subscription string
+
+	// The start of the billing period being renewed. This is the subscription's
+	// current_period_end at the time the renewal was recorded.
+	PeriodStart time.Time
+}
+
+// RenewalStore provides the subscription renewals an application has recorded
+// as expected within a time range.
+type RenewalStore interface {
+	ExpectedRenewals(start, end time.Time) ([]*ExpectedRenewal, error)
+}
+
+// RenewalReport is the result of reconciling expected renewals against the
+// invoice.payment_succeeded events that were received.
+type RenewalReport struct {
+	// Renewals that were expected but for which no payment event was received.
+	Missing []*ExpectedRenewal
+
+	// Renewals that were paid by more than one invoice, keyed by subscription.
+	Duplicates map[string][]*Invoice
+
+	// Paid subscription invoices for which no renewal was expected.
+	Unexpected []*Invoice
+}
+
+// OK returns true if every expected renewal was billed exactly once, and
+// nothing unexpected was billed.
+func (r *RenewalReport) OK() bool {
+	return len(r.Missing) == 0 && len(r.Duplicates) == 0 && len(r.Unexpected) == 0
+}
+
+// ReconcileRenewals cross-checks the renewals the store expects between start
+// and end against the given events, reporting renewals that were never billed
+// (usually a webhook delivery gap) and renewals billed more than once.
+//
+// Events other than invoice.payment_succeeded, and invoices that are not for a
+// subscription, are ignored. Events delivered more than once for the same
+// invoice are only counted once.
+func ReconcileRenewals(store RenewalStore, start, end time.Time, events []*Event) (*RenewalReport, error) {
+	expected, err := store.ExpectedRenewals(start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	// group the paid invoices by subscription and period
+	paid := make(map[renewalKey][]*Invoice)
+	seen := make(map[string]bool)
+	for _, e := range events {
+		if e.Type != EventInvoicePaymentSucceeded {
+			continue
+		}
+		inv := &Invoice{}
+		if err := e.Decode(inv); err != nil {
+			return nil, err
+		}
+		if inv.Subscription == "" || seen[inv.ID] {
+			continue
+		}
+		seen[inv.ID] = true
+		key := renewalKey{inv.Subscription, invoicePeriodStart(inv).Unix()}
+		paid[key] = append(paid[key], inv)
+	}
+
+	report := &RenewalReport{Duplicates: make(map[string][]*Invoice)}
+	for _, r := range expected {
+		key := renewalKey{r.Subscription, r.PeriodStart.Unix()}
+		invoices, ok := paid[key]
+		switch {
+		case !ok:
+			report.Missing = append(report.Missing, r)
+		case len(invoices) > 1:
+			report.Duplicates[r.Subscription] = append(report.Duplicates[r.Subscription], invoices...)
+		}
+		delete(paid, key)
+	}
+	for _, invoices := range paid {
+		report.Unexpected = append(report.Unexpected, invoices...)
+	}
+	return report, nil
+}
+
+type renewalKey struct {
+	subscription string
+	periodStart  int64
+}
+
+// invoicePeriodStart returns the start of the subscription period an invoice
+// bills for, which is the period of its subscription line item.
+func invoicePeriodStart(inv *Invoice) time.Time {
+	if inv.Lines != nil {
+		for _, line := range inv.Lines.Data {
+			if line.IsSubscription() {
+				return line.Period.Start.Time
+			}
+		}
+	}
+	return inv.PeriodStart.Time
+}
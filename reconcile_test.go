@@ -0,0 +1,62 @@
+package stripe
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+type renewalStore []*ExpectedRenewal
+
+func (s renewalStore) ExpectedRenewals(start, end time.Time) ([]*ExpectedRenewal, error) {
+	return s, nil
+}
+
+// paidEvent returns an invoice.payment_succeeded event for the given invoice,
+// subscription and period start.
+func paidEvent(eventID, invoiceID, subID string, start int64) *Event {
+	e := &Event{ID: eventID, Type: EventInvoicePaymentSucceeded}
+	e.Data.Object = []byte(fmt.Sprintf(`{
+		"id": %q,
+		"subscription": %q,
+		"lines": {"data": [{"type": "subscription", "period": {"start": %d, "end": %d}}]}
+	}`, invoiceID, subID, start, start+30*24*60*60))
+	return e
+}
+
+// TestReconcileRenewals will test that missing, duplicate and unexpected
+// renewals are reported, and that redelivered events are not counted twice.
+func TestReconcileRenewals(t *testing.T) {
+	period := time.Unix(1400000000, 0)
+	store := renewalStore{
+		{Customer: "cus_1", Subscription: "sub_1", PeriodStart: period},
+		{Customer: "cus_2", Subscription: "sub_2", PeriodStart: period},
+		{Customer: "cus_3", Subscription: "sub_3", PeriodStart: period},
+	}
+	events := []*Event{
+		paidEvent("evt_1", "in_1", "sub_1", period.Unix()),
+		paidEvent("evt_2", "in_1", "sub_1", period.Unix()), // redelivered
+		paidEvent("evt_3", "in_3a", "sub_3", period.Unix()),
+		paidEvent("evt_4", "in_3b", "sub_3", period.Unix()),
+		paidEvent("evt_5", "in_4", "sub_4", period.Unix()),
+		{ID: "evt_6", Type: EventChargeSucceeded},
+	}
+
+	report, err := ReconcileRenewals(store, period, period, events)
+	if err != nil {
+		t.Errorf("Expected Renewal Report, got Error %s", err.Error())
+		return
+	}
+	if report.OK() {
+		t.Errorf("Expected Renewal Report with problems, got OK")
+	}
+	if len(report.Missing) != 1 || report.Missing[0].Subscription != "sub_2" {
+		t.Errorf("Expected sub_2 Missing, got %+v", report.Missing)
+	}
+	if len(report.Duplicates) != 1 || len(report.Duplicates["sub_3"]) != 2 {
+		t.Errorf("Expected 2 sub_3 Duplicates, got %+v", report.Duplicates)
+	}
+	if len(report.Unexpected) != 1 || report.Unexpected[0].ID != "in_4" {
+		t.Errorf("Expected in_4 Unexpected, got %+v", report.Unexpected)
+	}
+}
@@ -0,0 +1,81 @@
+package stripe
+
+import (
+	"sort"
+	"time"
+)
+
+// ChargeBreakdown aggregates the charges made with cards of one brand, issued
+// in one country, in one currency.
+type ChargeBreakdown struct {
+	Brand    string
+	Country  string
+	Currency string
+
+	// The number of charges attempted, and how many of them were declined.
+	Count    int
+	Declined int
+
+	// The total amount, in cents, of the successful charges.
+	Volume int
+}
+
+// DeclineRate returns the fraction of charges that were declined, between 0
+// and 1.
+func (b *ChargeBreakdown) DeclineRate() float64 {
+	if b.Count == 0 {
+		return 0
+	}
+	return float64(b.Declined) / float64(b.Count)
+}
+
+// BreakdownCharges aggregates the charges by card brand, card country and
+// currency, sorted in that order. Charges without a card are grouped under
+// an empty brand and country.
+func BreakdownCharges(charges []*Charge) []*ChargeBreakdown {
+	type key struct{ brand, country, currency string }
+	groups := make(map[key]*ChargeBreakdown)
+	for _, c := range charges {
+		k := key{currency: c.Currency}
+		if c.Card != nil {
+			k.brand, k.country = c.Card.Type, c.Card.Country
+		}
+		b, ok := groups[k]
+		if !ok {
+			b = &ChargeBreakdown{Brand: k.brand, Country: k.country, Currency: k.currency}
+			groups[k] = b
+		}
+		b.Count++
+		if c.Paid {
+			b.Volume += c.Amount
+		} else {
+			b.Declined++
+		}
+	}
+
+	report := make([]*ChargeBreakdown, 0, len(groups))
+	for _, b := range groups {
+		report = append(report, b)
+	}
+	sort.Slice(report, func(i, j int) bool {
+		a, b := report[i], report[j]
+		if a.Brand != b.Brand {
+			return a.Brand < b.Brand
+		}
+		if a.Country != b.Country {
+			return a.Country < b.Country
+		}
+		return a.Currency < b.Currency
+	})
+	return report
+}
+
+// ChargeBreakdownReport lists every charge created between start and end and
+// aggregates them with BreakdownCharges.
+func ChargeBreakdownReport(start, end time.Time) ([]*ChargeBreakdown, error) {
+	charges, err := Charges.listCreated(&DateFilter{Gte: &UnixTime{start}, Lte: &UnixTime{end}}, 0)
+	if err != nil {
+		return nil, err
+	}
+	return BreakdownCharges(charges), nil
+}
@@ -0,0 +1,238 @@
+package stripe
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Report Run Statuses
+const (
+	ReportRunPending   = "pending"
+	ReportRunSucceeded = "succeeded"
+	ReportRunFailed    = "failed"
+)
+
+// ErrReportRunPending is returned when downloading the result of a Report
+// Run that has not finished.
+var ErrReportRunPending = errors.New("stripe: report run has not finished")
+
+// the shortest interval at which Wait polls a report run
+var reportRunMinInterval = time.Second
+
+// ReportRun represents a request to generate a financial report of a given
+// ReportType. The run is pending until the report's file is available as
+// its Result.
+//
+// see https://stripe.com/docs/api#reporting_report_run_object
+type ReportRun struct {
+	ID          string               `json:"id"`
+	ReportType  string               `json:"report_type"`
+	Parameters  *ReportRunParameters `json:"parameters"`
+	Status      string               `json:"status"`
+	Error       string               `json:"error,omitempty"`
+	Result      *File                `json:"result,omitempty"`
+	SucceededAt *UnixTime            `json:"succeeded_at,omitempty"`
+	Created     UnixTime             `json:"created"`
+	Livemode    bool                 `json:"livemode"`
+}
+
+// ReportRunParameters encapsulates the options for a Report Run. Which
+// options are supported depends on the ReportType.
+type ReportRunParameters struct {
+	// (Optional) The start and end of the period covered by the report.
+	// Both must be within the report type's available data.
+	IntervalStart *UnixTime `json:"interval_start,omitempty"`
+	IntervalEnd   *UnixTime `json:"interval_end,omitempty"`
+
+	// (Optional) The columns to include in the report, in order. The
+	// report type's default columns are used if empty.
+	Columns []string `json:"columns,omitempty"`
+
+	// (Optional) Restricts the report to the given currency.
+	Currency string `json:"currency,omitempty"`
+
+	// (Optional) The time zone used for the report's dates, such as
+	// "America/New_York". The default is UTC.
+	Timezone string `json:"timezone,omitempty"`
+}
+
+// ReportRunClient encapsulates operations for creating and querying report
+// runs using the Stripe REST API.
+type ReportRunClient struct{}
+
+// Creates a new Report Run of the ReportType with the given ID. The run is
+// pending when created; use Wait or Get to poll its status.
+//
+// see https://stripe.com/docs/api#reporting_report_run_create
+func (ReportRunClient) Create(reportType string, params *ReportRunParameters) (*ReportRun, error) {
+	values := url.Values{"report_type": {reportType}}
+	if params != nil {
+		if params.IntervalStart != nil {
+			values.Add("parameters[interval_start]", strconv.FormatInt(params.IntervalStart.Unix(), 10))
+		}
+		if params.IntervalEnd != nil {
+			values.Add("parameters[interval_end]", strconv.FormatInt(params.IntervalEnd.Unix(), 10))
+		}
+		for _, column := range params.Columns {
+			values.Add("parameters[columns][]", column)
+		}
+		if params.Currency != "" {
+			values.Add("parameters[currency]", params.Currency)
+		}
+		if params.Timezone != "" {
+			values.Add("parameters[timezone]", params.Timezone)
+		}
+	}
+	res := &ReportRun{}
+	return res, query("POST", "/reporting/report_runs", values, res)
+}
+
+// Retrieves the Report Run with the given ID.
+//
+// see https://stripe.com/docs/api#reporting_report_run_retrieve
+func (ReportRunClient) Get(id string) (*ReportRun, error) {
+	res := &ReportRun{}
+	return res, query("GET", "/reporting/report_runs/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of your Report Runs.
+//
+// see https://stripe.com/docs/api#reporting_report_run_list
+func (ReportRunClient) List(limit int, before, after string) ([]*ReportRun, bool, error) {
+	var data []*ReportRun
+	list, err := queryList("/reporting/report_runs", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+// Wait polls the Report Run with the given ID at the given interval until it
+// is no longer pending, returning the finished run. Intervals shorter than a
+// second are raised to a second. Runs which fail are returned with an error
+// containing the reason.
+func (c ReportRunClient) Wait(ctx context.Context, id string, interval time.Duration) (*ReportRun, error) {
+	if interval < reportRunMinInterval {
+		interval = reportRunMinInterval
+	}
+	for {
+		run, err := c.Get(id)
+		if err != nil {
+			return run, err
+		}
+		switch run.Status {
+		case ReportRunSucceeded:
+			return run, nil
+		case ReportRunFailed:
+			return run, fmt.Errorf("stripe: report run %s failed: %s", id, run.Error)
+		}
+
+		select {
+		case <-ctx.Done():
+			return run, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Download writes the result of the Report Run with the given ID to w. It
+// returns ErrReportRunPending if the run has not finished, and an error if
+// the result is not on Stripe's files or API host, since downloading it
+// requires the API key.
+func (c ReportRunClient) Download(ctx context.Context, id string, w io.Writer) error {
+	run, err := c.Get(id)
+	if err != nil {
+		return err
+	}
+	if run.Status == ReportRunFailed {
+		return fmt.Errorf("stripe: report run %s failed: %s", id, run.Error)
+	}
+	if run.Status != ReportRunSucceeded || run.Result == nil || run.Result.URL == "" {
+		return ErrReportRunPending
+	}
+
+	// the file's contents require the same authentication as the API, so
+	// the key is only sent to Stripe's own files or API host
+	endpoint, err := url.Parse(run.Result.URL)
+	if err != nil {
+		return err
+	}
+	if !trustedHost(endpoint) {
+		return fmt.Errorf("stripe: refusing to download report run result from %s", endpoint.Host)
+	}
+	endpoint.User = url.User(_key)
+
+	req, err := http.NewRequestWithContext(ctx, "GET", endpoint.String(), nil)
+	if err != nil {
+		return err
+	}
+	if _log {
+		fmt.Println("REQUEST: ", "GET", endpoint.String())
+	}
+	r, err := do(req, strings.TrimPrefix(endpoint.Path, "/v1"))
+	if err != nil {
+		return err
+	}
+	defer r.Body.Close()
+	if _log {
+		fmt.Println("RESPONSE: ", r.StatusCode)
+	}
+	if r.StatusCode != 200 {
+		return fmt.Errorf("stripe: downloading report run result: %s", r.Status)
+	}
+	_, err = io.Copy(w, r.Body)
+	return err
+}
+
+// trustedHost returns whether u has the same scheme and host as the files
+// or API URL, so that it may be sent the API key. Both are https unless
+// overridden with SetUploadUrl or SetUrl.
+func trustedHost(u *url.URL) bool {
+	for _, base := range []string{_uploadUrl, _url} {
+		if b, err := url.Parse(base); err == nil && b.Scheme == u.Scheme && b.Host == u.Host {
+			return u.Scheme != "" && u.Host != ""
+		}
+	}
+	return false
+}
+
+// ReportType represents a kind of financial report that can be run, such as
+// "balance.summary.1", along with the period for which its data is
+// available.
+//
+// see https://stripe.com/docs/api#reporting_report_type_object
+type ReportType struct {
+	ID                 string   `json:"id"`
+	Name               string   `json:"name"`
+	Version            int      `json:"version"`
+	DefaultColumns     []string `json:"default_columns,omitempty"`
+	DataAvailableStart UnixTime `json:"data_available_start"`
+	DataAvailableEnd   UnixTime `json:"data_available_end"`
+	Updated            UnixTime `json:"updated"`
+	Livemode           bool     `json:"livemode"`
+}
+
+// ReportTypeClient encapsulates operations for querying report types using
+// the Stripe REST API.
+type ReportTypeClient struct{}
+
+// Retrieves the Report Type with the given ID.
+//
+// see https://stripe.com/docs/api#reporting_report_type_retrieve
+func (ReportTypeClient) Get(id string) (*ReportType, error) {
+	res := &ReportType{}
+	return res, query("GET", "/reporting/report_types/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns all of the Report Types. The list is not paginated.
+//
+// see https://stripe.com/docs/api#reporting_report_type_list
+func (ReportTypeClient) List() ([]*ReportType, error) {
+	var data []*ReportType
+	_, err := queryList("/reporting/report_types", nil, &data)
+	return data, err
+}
@@ -0,0 +1,142 @@
+package stripe
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+// TestCreateReportRun will test that a Report Run is created with its
+// interval and columns.
+func TestCreateReportRun(t *testing.T) {
+	req, done := mockServer(`{"id": "frr_1", "report_type": "balance.summary.1", "status": "pending", "parameters": {"interval_start": 1500000000, "columns": ["category", "net"]}}`)
+	defer done()
+
+	run, err := ReportRuns.Create("balance.summary.1", &ReportRunParameters{
+		IntervalStart: &UnixTime{time.Unix(1500000000, 0)},
+		IntervalEnd:   &UnixTime{time.Unix(1500086400, 0)},
+		Columns:       []string{"category", "net"},
+	})
+	if err != nil {
+		t.Errorf("Expected Report Run, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/reporting/report_runs" {
+		t.Errorf("Expected POST /v1/reporting/report_runs, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"report_type":                "balance.summary.1",
+		"parameters[interval_start]": "1500000000",
+		"parameters[interval_end]":   "1500086400",
+	})
+	if got := req.Form["parameters[columns][]"]; len(got) != 2 || got[0] != "category" || got[1] != "net" {
+		t.Errorf("Expected columns category, net, got %v", got)
+	}
+	if run.Status != ReportRunPending || len(run.Parameters.Columns) != 2 {
+		t.Errorf("Expected pending Report Run with 2 columns, got %+v", run)
+	}
+}
+
+// TestWaitAndDownloadReportRun will test that a Report Run is polled until it
+// succeeds, and that its result is downloaded with the API key.
+func TestWaitAndDownloadReportRun(t *testing.T) {
+	minInterval := reportRunMinInterval
+	reportRunMinInterval = time.Millisecond
+	defer func() { reportRunMinInterval = minInterval }()
+
+	polls := 0
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v1/reporting/report_runs/frr_1":
+			if polls++; polls < 3 {
+				fmt.Fprint(w, `{"id": "frr_1", "status": "pending"}`)
+				return
+			}
+			fmt.Fprintf(w, `{"id": "frr_1", "status": "succeeded", "result": {"id": "file_1", "url": "%s/v1/files/file_1/contents"}}`, srv.URL)
+		case "/v1/files/file_1/contents":
+			if user, _, _ := r.BasicAuth(); user != _key {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			fmt.Fprint(w, "category,net\ncharge,1000\n")
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	prev := _url
+	SetUrl(srv.URL)
+	defer SetUrl(prev)
+
+	var buf bytes.Buffer
+	if err := ReportRuns.Download(context.Background(), "frr_1", &buf); err != ErrReportRunPending {
+		t.Errorf("Expected ErrReportRunPending, got %v", err)
+	}
+
+	run, err := ReportRuns.Wait(context.Background(), "frr_1", time.Millisecond)
+	if err != nil {
+		t.Errorf("Expected Report Run, got Error %s", err.Error())
+		return
+	}
+	if run.Status != ReportRunSucceeded || polls != 3 {
+		t.Errorf("Expected Report Run to succeed after 3 polls, got %s after %d", run.Status, polls)
+	}
+
+	if err := ReportRuns.Download(context.Background(), "frr_1", &buf); err != nil {
+		t.Errorf("Expected Report Run result, got Error %s", err.Error())
+	}
+	if buf.String() != "category,net\ncharge,1000\n" {
+		t.Errorf("Expected Report Run result contents, got %q", buf.String())
+	}
+}
+
+// TestWaitReportRunMinInterval will test that a run is not polled in a tight
+// loop when no interval is given.
+func TestWaitReportRunMinInterval(t *testing.T) {
+	prev := reportRunMinInterval
+	reportRunMinInterval = 50 * time.Millisecond
+	defer func() { reportRunMinInterval = prev }()
+
+	polls := 0
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		polls++
+		w.Write([]byte(`{"id": "frr_1", "status": "pending"}`))
+	})
+	defer done()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 120*time.Millisecond)
+	defer cancel()
+	if _, err := ReportRuns.Wait(ctx, "frr_1", 0); err != context.DeadlineExceeded {
+		t.Errorf("Expected deadline exceeded, got %v", err)
+	}
+	if polls > 3 {
+		t.Errorf("Expected at most 3 polls, got %d", polls)
+	}
+}
+
+// TestDownloadReportRunUntrustedHost will test that a result on any host
+// other than Stripe's files or API host is not sent the API key.
+func TestDownloadReportRunUntrustedHost(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("Expected no request to untrusted host, got %s", r.URL.Path)
+	}))
+	defer other.Close()
+
+	for _, result := range []string{
+		other.URL + "/v1/files/file_1/contents",
+		"http://files.stripe.com/v1/files/file_1/contents",
+		"https://files.stripe.com.example.com/v1/files/file_1/contents",
+	} {
+		_, done := mockServer(fmt.Sprintf(`{"id": "frr_1", "status": "succeeded", "result": {"id": "file_1", "url": "%s"}}`, result))
+		err := ReportRuns.Download(context.Background(), "frr_1", &bytes.Buffer{})
+		done()
+		if err == nil || err == ErrReportRunPending {
+			t.Errorf("Expected untrusted host error for %s, got %v", result, err)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestChargeBreakdownReport will test that charges are aggregated by card
+// brand and country, counting declines and successful volume.
+func TestChargeBreakdownReport(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [
+		{"id": "ch_1", "paid": true, "amount": 1000, "currency": "usd", "card": {"type": "Visa", "country": "US"}},
+		{"id": "ch_2", "paid": false, "amount": 500, "currency": "usd", "card": {"type": "Visa", "country": "US"}},
+		{"id": "ch_3", "paid": true, "amount": 700, "currency": "usd", "card": {"type": "Visa", "country": "US"}},
+		{"id": "ch_4", "paid": false, "amount": 300, "currency": "eur", "card": {"type": "MasterCard", "country": "DE"}}
+	]}`)
+	defer done()
+
+	report, err := ChargeBreakdownReport(time.Unix(1500000000, 0), time.Unix(1500086400, 0))
+	if err != nil {
+		t.Errorf("Expected Charge Breakdown, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"created[gte]": "1500000000",
+		"created[lte]": "1500086400",
+		"limit":        "100",
+	})
+	if len(report) != 2 {
+		t.Errorf("Expected 2 groups, got %d", len(report))
+		return
+	}
+
+	mc, visa := report[0], report[1]
+	if mc.Brand != MasterCard || mc.Country != "DE" || mc.Count != 1 || mc.DeclineRate() != 1 || mc.Volume != 0 {
+		t.Errorf("Expected 1 declined MasterCard charge from DE, got %+v", mc)
+	}
+	if visa.Brand != Visa || visa.Count != 3 || visa.Declined != 1 || visa.Volume != 1700 {
+		t.Errorf("Expected 3 Visa charges with 1 declined and 1700 volume, got %+v", visa)
+	}
+}
@@ -0,0 +1,62 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Review Reasons
+const (
+	ReviewRule            = "rule"
+	ReviewManual          = "manual"
+	ReviewApproved        = "approved"
+	ReviewRefunded        = "refunded"
+	ReviewRefundedAsFraud = "refunded_as_fraud"
+	ReviewDisputed        = "disputed"
+)
+
+// Review represents a payment flagged by Radar for manual review. A review is
+// closed when the payment is approved or refunded.
+//
+// see https://stripe.com/docs/api#review_object
+type Review struct {
+	ID            string   `json:"id"`
+	Charge        string   `json:"charge,omitempty"`
+	PaymentIntent string   `json:"payment_intent,omitempty"`
+	Open          bool     `json:"open"`
+	Reason        string   `json:"reason"`
+	OpenedReason  string   `json:"opened_reason,omitempty"`
+	ClosedReason  string   `json:"closed_reason,omitempty"`
+	IPAddress     string   `json:"ip_address,omitempty"`
+	Created       UnixTime `json:"created"`
+	Livemode      bool     `json:"livemode"`
+}
+
+// ReviewClient encapsulates operations for querying and approving Radar
+// reviews using the Stripe REST API.
+type ReviewClient struct{}
+
+// Retrieves the Review with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_review
+func (ReviewClient) Get(id string) (*Review, error) {
+	res := &Review{}
+	return res, query("GET", "/reviews/"+url.QueryEscape(id), nil, res)
+}
+
+// Approves the payment under the Review with the given ID, closing the
+// review.
+//
+// see https://stripe.com/docs/api#approve_review
+func (ReviewClient) Approve(id string) (*Review, error) {
+	res := &Review{}
+	return res, query("POST", "/reviews/"+url.QueryEscape(id)+"/approve", nil, res)
+}
+
+// Returns a list of the open Reviews.
+//
+// see https://stripe.com/docs/api#list_reviews
+func (ReviewClient) List(limit int, before, after string) ([]*Review, bool, error) {
+	var data []*Review
+	list, err := queryList("/reviews", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
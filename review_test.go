@@ -0,0 +1,24 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestApproveReview will test that approving a Review posts to its approve
+// endpoint, returning the closed review.
+func TestApproveReview(t *testing.T) {
+	req, done := mockServer(`{"id": "prv_1", "charge": "ch_1", "open": false, "reason": "approved", "opened_reason": "rule", "closed_reason": "approved"}`)
+	defer done()
+
+	review, err := Reviews.Approve("prv_1")
+	if err != nil {
+		t.Errorf("Expected Review, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/reviews/prv_1/approve" {
+		t.Errorf("Expected POST /v1/reviews/prv_1/approve, got %s %s", req.Method, req.Path)
+	}
+	if review.Open || review.ClosedReason != ReviewApproved || review.Charge != "ch_1" {
+		t.Errorf("Expected Review of ch_1 closed as approved, got %+v", review)
+	}
+}
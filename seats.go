@@ -0,0 +1,60 @@
+package stripe
+
+// SeatChange describes the cost of changing the quantity (number of seats)
+// of a subscription.
+type SeatChange struct {
+	// The current and proposed quantity of the subscription.
+	Current  int
+	Proposed int
+
+	// The prorated amount, in cents, for the rest of the current billing
+	// period. It is positive when adding seats and negative (a credit) when
+	// removing them, and is billed on the next invoice.
+	ProratedAmount int
+
+	// The amount, in cents, billed each period once the change takes effect.
+	RecurringAmount int
+
+	Currency      string
+	Interval      string
+	IntervalCount int
+}
+
+// PreviewSeatChange previews changing the quantity of a customer's
+// subscription, using the upcoming invoice to calculate the prorated amount
+// for the current period. Nothing is changed on the subscription.
+func PreviewSeatChange(customerID, subscriptionID string, quantity int) (*SeatChange, error) {
+	sub, err := Subscriptions.Get(customerID, subscriptionID)
+	if err != nil {
+		return nil, err
+	}
+
+	inv, err := Invoices.Upcoming(customerID, &UpcomingParams{
+		Subscription:  subscriptionID,
+		Quantity:      &quantity,
+		ProrationDate: &UnixTime{_clock.Now()},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	change := &SeatChange{
+		Current:  sub.Quantity,
+		Proposed: quantity,
+		Currency: inv.Currency,
+	}
+	if sub.Plan != nil {
+		change.RecurringAmount = sub.Plan.Amount * quantity
+		change.Currency = sub.Plan.Currency
+		change.Interval = sub.Plan.Interval
+		change.IntervalCount = sub.Plan.IntervalCount
+	}
+	if inv.Lines != nil {
+		for _, line := range inv.Lines.Data {
+			if line.IsProration() {
+				change.ProratedAmount += line.Amount
+			}
+		}
+	}
+	return change, nil
+}
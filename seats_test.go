@@ -0,0 +1,43 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestPreviewSeatChange will test that the prorated and recurring amounts of a
+// quantity change are calculated from the upcoming invoice and the plan.
+func TestPreviewSeatChange(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/customers/cus_1/subscriptions/sub_1": `{"id": "sub_1", "quantity": 5, "plan": {"id": "seat", "amount": 1000, "currency": "usd", "interval": "month", "interval_count": 1}}`,
+		"GET /v1/invoices/upcoming": `{"currency": "usd", "lines": {"data": [
+			{"type": "invoiceitem", "proration": true, "amount": -2500},
+			{"type": "invoiceitem", "proration": true, "amount": 3500},
+			{"type": "subscription", "amount": 7000}
+		]}}`,
+	})
+	defer done()
+	SetClock(fixedClock(time.Unix(1500000000, 0)))
+	defer SetClock(nil)
+
+	change, err := PreviewSeatChange("cus_1", "sub_1", 7)
+	if err != nil {
+		t.Errorf("Expected Seat Change, got Error %s", err.Error())
+		return
+	}
+	if got := (*reqs)[1].Form.Get("subscription_quantity"); got != "7" {
+		t.Errorf("Expected subscription_quantity 7, got %q", got)
+	}
+	if got := (*reqs)[1].Form.Get("subscription_proration_date"); got != "1500000000" {
+		t.Errorf("Expected subscription_proration_date 1500000000, got %q", got)
+	}
+	if change.Current != 5 || change.Proposed != 7 {
+		t.Errorf("Expected change from 5 to 7 seats, got %d to %d", change.Current, change.Proposed)
+	}
+	if change.ProratedAmount != 1000 {
+		t.Errorf("Expected Prorated Amount 1000, got %d", change.ProratedAmount)
+	}
+	if change.RecurringAmount != 7000 || change.Interval != IntervalMonth {
+		t.Errorf("Expected Recurring Amount 7000 per month, got %d per %s", change.RecurringAmount, change.Interval)
+	}
+}
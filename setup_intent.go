@@ -0,0 +1,184 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// SetupIntent Statuses
+const (
+	SetupIntentRequiresPaymentMethod = "requires_payment_method"
+	SetupIntentRequiresConfirmation  = "requires_confirmation"
+	SetupIntentRequiresAction        = "requires_action"
+	SetupIntentProcessing            = "processing"
+	SetupIntentCanceled              = "canceled"
+	SetupIntentSucceeded             = "succeeded"
+)
+
+// SetupIntent Usages
+const (
+	UsageOffSession = "off_session"
+	UsageOnSession  = "on_session"
+)
+
+// SetupIntent Cancellation Reasons
+const (
+	CancelAbandoned           = "abandoned"
+	CancelDuplicate           = "duplicate"
+	CancelRequestedByCustomer = "requested_by_customer"
+)
+
+// SetupIntent guides the process of saving a customer's payment details for
+// future payments, including any authentication required by SCA.
+//
+// see https://stripe.com/docs/api#setup_intent_object
+type SetupIntent struct {
+	ID                 string            `json:"id"`
+	ClientSecret       string            `json:"client_secret"`
+	Customer           string            `json:"customer,omitempty"`
+	Description        string            `json:"description,omitempty"`
+	PaymentMethod      string            `json:"payment_method,omitempty"`
+	PaymentMethodTypes []string          `json:"payment_method_types"`
+	Status             string            `json:"status"`
+	Usage              string            `json:"usage"`
+	CancellationReason string            `json:"cancellation_reason,omitempty"`
+	Mandate            string            `json:"mandate,omitempty"`
+	LastSetupError     *SetupError       `json:"last_setup_error,omitempty"`
+	Created            UnixTime          `json:"created"`
+	Livemode           bool              `json:"livemode"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// SetupError describes why the last attempt to set up a SetupIntent failed.
+type SetupError struct {
+	Code        string `json:"code,omitempty"`
+	DeclineCode string `json:"decline_code,omitempty"`
+	Message     string `json:"message,omitempty"`
+	Type        string `json:"type"`
+}
+
+// SetupIntentParams encapsulates options for creating a SetupIntent.
+type SetupIntentParams struct {
+	// (Optional) The ID of the customer the payment method is saved to.
+	Customer string
+
+	// (Optional) The ID of the payment method to set up.
+	PaymentMethod string
+
+	// (Optional) The payment method types that may be used. Defaults to card.
+	PaymentMethodTypes []string
+
+	// (Optional) How the payment method will be used in future. Either
+	// off_session (the default) or on_session.
+	Usage string
+
+	// (Optional) An arbitrary string attached to the SetupIntent.
+	Description string
+
+	// (Optional) Whether to confirm the SetupIntent immediately.
+	Confirm *bool
+
+	// (Optional) The URL to redirect the customer back to after they
+	// authenticate. Only used when confirming.
+	ReturnURL string
+
+	Metadata map[string]string
+}
+
+// SetupIntentConfirmParams encapsulates options for confirming a SetupIntent.
+type SetupIntentConfirmParams struct {
+	// (Optional) The ID of the payment method to set up, if not already set.
+	PaymentMethod string
+
+	// (Optional) The URL to redirect the customer back to after they
+	// authenticate.
+	ReturnURL string
+}
+
+// SetupIntentClient encapsulates operations for creating, confirming,
+// canceling and querying SetupIntents using the Stripe REST API.
+type SetupIntentClient struct{}
+
+// Creates a new SetupIntent.
+//
+// see https://stripe.com/docs/api#create_setup_intent
+func (SetupIntentClient) Create(params *SetupIntentParams) (*SetupIntent, error) {
+	values := make(url.Values)
+	if params.Customer != "" {
+		values.Add("customer", params.Customer)
+	}
+	if params.PaymentMethod != "" {
+		values.Add("payment_method", params.PaymentMethod)
+	}
+	for _, typ := range params.PaymentMethodTypes {
+		values.Add("payment_method_types[]", typ)
+	}
+	if params.Usage != "" {
+		values.Add("usage", params.Usage)
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.Confirm != nil {
+		values.Add("confirm", strconv.FormatBool(*params.Confirm))
+	}
+	if params.ReturnURL != "" {
+		values.Add("return_url", params.ReturnURL)
+	}
+	appendMetadata(values, params.Metadata)
+
+	res := &SetupIntent{}
+	return res, query("POST", "/setup_intents", values, res)
+}
+
+// Retrieves the SetupIntent with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_setup_intent
+func (SetupIntentClient) Get(id string) (*SetupIntent, error) {
+	res := &SetupIntent{}
+	return res, query("GET", "/setup_intents/"+url.QueryEscape(id), nil, res)
+}
+
+// Confirms that the customer intends to save the payment method, starting any
+// required authentication.
+//
+// see https://stripe.com/docs/api#confirm_setup_intent
+func (SetupIntentClient) Confirm(id string, params *SetupIntentConfirmParams) (*SetupIntent, error) {
+	values := make(url.Values)
+	if params != nil {
+		if params.PaymentMethod != "" {
+			values.Add("payment_method", params.PaymentMethod)
+		}
+		if params.ReturnURL != "" {
+			values.Add("return_url", params.ReturnURL)
+		}
+	}
+	res := &SetupIntent{}
+	return res, query("POST", "/setup_intents/"+url.QueryEscape(id)+"/confirm", values, res)
+}
+
+// Cancels the SetupIntent with the given ID. The reason is optional.
+//
+// see https://stripe.com/docs/api#cancel_setup_intent
+func (SetupIntentClient) Cancel(id, reason string) (*SetupIntent, error) {
+	values := make(url.Values)
+	if reason != "" {
+		values.Add("cancellation_reason", reason)
+	}
+	res := &SetupIntent{}
+	return res, query("POST", "/setup_intents/"+url.QueryEscape(id)+"/cancel", values, res)
+}
+
+// Returns a list of SetupIntents for the given customer (or all customers, if
+// empty) at the specified range.
+//
+// see https://stripe.com/docs/api#list_setup_intents
+func (SetupIntentClient) List(customerID string, limit int, before, after string) ([]*SetupIntent, bool, error) {
+	var data []*SetupIntent
+	params := listParams(limit, before, after)
+	if customerID != "" {
+		params.Add("customer", customerID)
+	}
+	list, err := queryList("/setup_intents", params, &data)
+	return data, list.More, err
+}
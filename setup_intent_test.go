@@ -0,0 +1,31 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateSetupIntent will test that a SetupIntent is created for off
+// session use, and that its client secret and status are decoded.
+func TestCreateSetupIntent(t *testing.T) {
+	req, done := mockServer(`{"id": "seti_1", "client_secret": "seti_1_secret", "status": "requires_payment_method", "usage": "off_session"}`)
+	defer done()
+
+	intent, err := SetupIntents.Create(&SetupIntentParams{
+		Customer:           "cus_1",
+		PaymentMethodTypes: []string{"card", "sepa_debit"},
+		Usage:              UsageOffSession,
+	})
+	if err != nil {
+		t.Errorf("Expected SetupIntent, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/setup_intents" {
+		t.Errorf("Expected POST /v1/setup_intents, got %s %s", req.Method, req.Path)
+	}
+	if types := req.Form["payment_method_types[]"]; len(types) != 2 || types[1] != "sepa_debit" {
+		t.Errorf("Expected payment_method_types [card sepa_debit], got %v", types)
+	}
+	if intent.ClientSecret != "seti_1_secret" || intent.Status != SetupIntentRequiresPaymentMethod {
+		t.Errorf("Expected SetupIntent requiring a payment method, got %+v", intent)
+	}
+}
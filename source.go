@@ -0,0 +1,300 @@
+package stripe
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+)
+
+// Source Types with typed details
+const (
+	SourceTypeAlipay = "alipay"
+	SourceTypeWeChat = "wechat"
+)
+
+// Source Flows
+const (
+	FlowRedirect         = "redirect"
+	FlowReceiver         = "receiver"
+	FlowCodeVerification = "code_verification"
+	FlowNone             = "none"
+)
+
+// Source Statuses
+const (
+	SourcePending    = "pending"
+	SourceChargeable = "chargeable"
+	SourceConsumed   = "consumed"
+	SourceCanceled   = "canceled"
+	SourceFailed     = "failed"
+)
+
+// Source Usages
+const (
+	UsageReusable  = "reusable"
+	UsageSingleUse = "single_use"
+)
+
+// Source represents a payment instrument, such as a card or a redirect-based
+// payment method, that can be charged once it is chargeable.
+//
+// see https://stripe.com/docs/api#source_object
+type Source struct {
+	ID                  string            `json:"id"`
+	Type                string            `json:"type"`
+	Amount              int               `json:"amount,omitempty"`
+	Currency            string            `json:"currency,omitempty"`
+	Flow                string            `json:"flow"`
+	Status              string            `json:"status"`
+	Usage               string            `json:"usage"`
+	ClientSecret        string            `json:"client_secret"`
+	Customer            string            `json:"customer,omitempty"`
+	Owner               *SourceOwner      `json:"owner,omitempty"`
+	Redirect            *SourceRedirect   `json:"redirect,omitempty"`
+	Alipay              *SourceAlipay     `json:"alipay,omitempty"`
+	WeChat              *SourceWeChat     `json:"wechat,omitempty"`
+	StatementDescriptor string            `json:"statement_descriptor,omitempty"`
+	Created             UnixTime          `json:"created"`
+	Livemode            bool              `json:"livemode"`
+	Metadata            map[string]string `json:"metadata,omitempty"`
+
+	// The details specific to the source's type, taken from the field named
+	// after the type (e.g. "card" for a card source).
+	TypeData map[string]interface{} `json:"-"`
+}
+
+// SourceOwner holds the information about the owner of a Source.
+type SourceOwner struct {
+	Name    string   `json:"name,omitempty"`
+	Email   string   `json:"email,omitempty"`
+	Phone   string   `json:"phone,omitempty"`
+	Address *Address `json:"address,omitempty"`
+}
+
+// SourceRedirect holds the redirect details of a Source using the redirect
+// flow. The customer must be sent to URL to authorize the payment.
+type SourceRedirect struct {
+	ReturnURL string `json:"return_url"`
+	Status    string `json:"status"`
+	URL       string `json:"url"`
+}
+
+// SourceAlipay holds the details of an Alipay Source. Customers authorize the
+// payment by following the Source's redirect URL, or the NativeURL when
+// paying from the Alipay app.
+type SourceAlipay struct {
+	DataString          string `json:"data_string,omitempty"`
+	NativeURL           string `json:"native_url,omitempty"`
+	StatementDescriptor string `json:"statement_descriptor,omitempty"`
+}
+
+// SourceWeChat holds the details of a WeChat Pay Source. Customers authorize
+// the payment by scanning the QR code at QRCodeURL, or from the WeChat app.
+type SourceWeChat struct {
+	QRCodeURL           string `json:"qr_code_url,omitempty"`
+	IOSNativeURL        string `json:"ios_native_url,omitempty"`
+	AndroidAppID        string `json:"android_appid,omitempty"`
+	StatementDescriptor string `json:"statement_descriptor,omitempty"`
+}
+
+// UnmarshalJSON decodes a Source, collecting the details specific to its type
+// into TypeData.
+func (s *Source) UnmarshalJSON(data []byte) error {
+	type source Source
+	if err := json.Unmarshal(data, (*source)(s)); err != nil {
+		return err
+	}
+	raw := make(map[string]json.RawMessage)
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+	s.TypeData = nil
+	if typeData, ok := raw[s.Type]; ok && s.Type != "" {
+		return json.Unmarshal(typeData, &s.TypeData)
+	}
+	return nil
+}
+
+// SourceParams encapsulates options for creating and updating Sources.
+type SourceParams struct {
+	// The type of Source to create. Required unless Token is set. When
+	// updating, the Source's type, which is not sent but is required to nest
+	// TypeData.
+	Type string
+
+	// (Optional) Create the Source from a token (e.g. a card token).
+	Token string
+
+	// (Optional) The amount, in cents, associated with the Source. Required
+	// for single use sources.
+	Amount *int
+
+	// (Optional) 3-letter ISO code for currency.
+	Currency string
+
+	// (Optional) The authentication flow of the Source.
+	Flow string
+
+	// (Optional) Either reusable or single_use.
+	Usage string
+
+	// (Optional) Information about the owner of the payment instrument.
+	Owner *SourceOwner
+
+	// (Optional) The URL the customer is redirected back to after
+	// authorizing a redirect flow source.
+	ReturnURL string
+
+	// (Optional) An arbitrary string to be displayed on your customer's
+	// statement.
+	StatementDescriptor string
+
+	// (Optional) Parameters specific to the Source's type, sent nested under
+	// the type (e.g. sofort[country]).
+	TypeData map[string]string
+
+	Metadata map[string]string
+}
+
+// NewAlipaySourceParams returns the params for a single use Alipay Source for
+// the given amount. The customer is redirected back to returnURL after
+// authorizing the payment.
+func NewAlipaySourceParams(amount int, currency, returnURL string) *SourceParams {
+	return &SourceParams{
+		Type:      SourceTypeAlipay,
+		Amount:    &amount,
+		Currency:  currency,
+		ReturnURL: returnURL,
+	}
+}
+
+// NewWeChatSourceParams returns the params for a single use WeChat Pay Source
+// for the given amount. The statement descriptor is optional.
+func NewWeChatSourceParams(amount int, currency, statementDescriptor string) *SourceParams {
+	return &SourceParams{
+		Type:                SourceTypeWeChat,
+		Amount:              &amount,
+		Currency:            currency,
+		StatementDescriptor: statementDescriptor,
+	}
+}
+
+// SourceClient encapsulates operations for creating, updating, attaching and
+// querying Sources using the Stripe REST API.
+type SourceClient struct{}
+
+// Creates a new Source.
+//
+// see https://stripe.com/docs/api#create_source
+func (c SourceClient) Create(params *SourceParams) (*Source, error) {
+	res := &Source{}
+	return res, query("POST", "/sources", c.createValues(params), res)
+}
+
+// Retrieves the Source with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_source
+func (SourceClient) Get(id string) (*Source, error) {
+	res := &Source{}
+	return res, query("GET", "/sources/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the owner, metadata or type-specific details of the Source with the
+// given ID. The other params cannot be updated and are not sent.
+//
+// see https://stripe.com/docs/api#update_source
+func (c SourceClient) Update(id string, params *SourceParams) (*Source, error) {
+	res := &Source{}
+	if len(params.TypeData) != 0 && params.Type == "" {
+		return res, &ParamError{"type", "is required to update type-specific details"}
+	}
+	return res, query("POST", "/sources/"+url.QueryEscape(id), c.updateValues(params.Type, params), res)
+}
+
+// Attaches the Source with the given ID to a customer, so it can be reused.
+//
+// see https://stripe.com/docs/api#attach_source
+func (SourceClient) Attach(customerID, sourceID string) (*Source, error) {
+	values := url.Values{"source": {sourceID}}
+	path := fmt.Sprintf("/customers/%s/sources", url.QueryEscape(customerID))
+	res := &Source{}
+	return res, query("POST", path, values, res)
+}
+
+// Detaches the Source with the given ID from a customer. A detached Source is
+// consumed and can no longer be used.
+//
+// see https://stripe.com/docs/api#detach_source
+func (SourceClient) Detach(customerID, sourceID string) (*Source, error) {
+	path := fmt.Sprintf("/customers/%s/sources/%s", url.QueryEscape(customerID), url.QueryEscape(sourceID))
+	res := &Source{}
+	return res, query("DELETE", path, nil, res)
+}
+
+// createValues encodes params for creating a Source, sending only the fields
+// that are set.
+func (SourceClient) createValues(params *SourceParams) url.Values {
+	values := make(url.Values)
+	if params.Type != "" {
+		values.Add("type", params.Type)
+	}
+	if params.Token != "" {
+		values.Add("token", params.Token)
+	}
+	if params.Amount != nil {
+		values.Add("amount", strconv.Itoa(*params.Amount))
+	}
+	if params.Currency != "" {
+		values.Add("currency", params.Currency)
+	}
+	if params.Flow != "" {
+		values.Add("flow", params.Flow)
+	}
+	if params.Usage != "" {
+		values.Add("usage", params.Usage)
+	}
+	appendSourceOwner(values, params.Owner)
+	if params.ReturnURL != "" {
+		values.Add("redirect[return_url]", params.ReturnURL)
+	}
+	if params.StatementDescriptor != "" {
+		values.Add("statement_descriptor", params.StatementDescriptor)
+	}
+	appendSourceTypeData(values, params.Type, params.TypeData)
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// updateValues encodes params for updating a Source of the given type. Only
+// the owner, type-specific details and metadata can be updated.
+func (SourceClient) updateValues(typ string, params *SourceParams) url.Values {
+	values := make(url.Values)
+	appendSourceOwner(values, params.Owner)
+	appendSourceTypeData(values, typ, params.TypeData)
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+func appendSourceOwner(values url.Values, o *SourceOwner) {
+	if o != nil {
+		if o.Name != "" {
+			values.Add("owner[name]", o.Name)
+		}
+		if o.Email != "" {
+			values.Add("owner[email]", o.Email)
+		}
+		if o.Phone != "" {
+			values.Add("owner[phone]", o.Phone)
+		}
+		if o.Address != nil {
+			appendAddress(values, "owner[address]", o.Address)
+		}
+	}
+}
+
+func appendSourceTypeData(values url.Values, typ string, data map[string]string) {
+	for k, v := range data {
+		values.Add(fmt.Sprintf("%s[%s]", typ, k), v)
+	}
+}
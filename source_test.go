@@ -0,0 +1,110 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateSource will test that a redirect Source is created with its
+// type-specific params, and that its type-specific details are decoded.
+func TestCreateSource(t *testing.T) {
+	req, done := mockServer(`{
+		"id": "src_1",
+		"type": "sofort",
+		"flow": "redirect",
+		"status": "pending",
+		"redirect": {"return_url": "https://example.com/return", "status": "pending", "url": "https://hooks.stripe.com/redirect/src_1"},
+		"sofort": {"country": "DE", "bank_name": "Deutsche Bank"}
+	}`)
+	defer done()
+
+	src, err := Sources.Create(&SourceParams{
+		Type:      "sofort",
+		Amount:    Int(1099),
+		Currency:  EUR,
+		ReturnURL: "https://example.com/return",
+		TypeData:  map[string]string{"country": "DE"},
+	})
+	if err != nil {
+		t.Errorf("Expected Source, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"type":                 "sofort",
+		"amount":               "1099",
+		"redirect[return_url]": "https://example.com/return",
+		"sofort[country]":      "DE",
+	})
+	if src.Redirect == nil || src.Redirect.URL != "https://hooks.stripe.com/redirect/src_1" {
+		t.Errorf("Expected Source Redirect URL, got %+v", src.Redirect)
+	}
+	if src.TypeData["bank_name"] != "Deutsche Bank" {
+		t.Errorf("Expected Source bank_name Deutsche Bank, got %v", src.TypeData)
+	}
+}
+
+// TestCreateWeChatSource will test that a WeChat Pay Source is created, and
+// that its QR code URL is decoded.
+func TestCreateWeChatSource(t *testing.T) {
+	req, done := mockServer(`{
+		"id": "src_2",
+		"type": "wechat",
+		"flow": "none",
+		"status": "pending",
+		"wechat": {"qr_code_url": "weixin://wxpay/bizpayurl?pr=abc", "statement_descriptor": "ORDER 42"}
+	}`)
+	defer done()
+
+	src, err := Sources.Create(NewWeChatSourceParams(1099, USD, "ORDER 42"))
+	if err != nil {
+		t.Errorf("Expected Source, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"type":                 SourceTypeWeChat,
+		"amount":               "1099",
+		"currency":             USD,
+		"statement_descriptor": "ORDER 42",
+	})
+	if src.WeChat == nil || src.WeChat.QRCodeURL != "weixin://wxpay/bizpayurl?pr=abc" {
+		t.Errorf("Expected WeChat QR Code URL, got %+v", src.WeChat)
+	}
+	if src.Alipay != nil {
+		t.Errorf("Expected no Alipay details, got %+v", src.Alipay)
+	}
+}
+
+// TestUpdateSource will test that only the updatable params are sent, with
+// the type-specific details nested under the given type.
+func TestUpdateSource(t *testing.T) {
+	req, done := mockServer(`{"id": "src_1", "type": "sofort"}`)
+	defer done()
+
+	_, err := Sources.Update("src_1", &SourceParams{
+		Type:      "sofort",
+		Amount:    Int(1099),
+		Currency:  EUR,
+		ReturnURL: "https://example.com/return",
+		Owner:     &SourceOwner{Email: "jenny@example.com"},
+		TypeData:  map[string]string{"preferred_language": "de"},
+		Metadata:  map[string]string{"order": "42"},
+	})
+	if err != nil {
+		t.Errorf("Expected Source, got Error %s", err.Error())
+		return
+	}
+	assertForm(t, req, map[string]string{
+		"owner[email]":               "jenny@example.com",
+		"sofort[preferred_language]": "de",
+		"metadata[order]":            "42",
+	})
+	for _, k := range []string{"type", "amount", "currency", "redirect[return_url]"} {
+		if _, ok := req.Form[k]; ok {
+			t.Errorf("Expected param %s not to be sent", k)
+		}
+	}
+
+	_, err = Sources.Update("src_1", &SourceParams{TypeData: map[string]string{"preferred_language": "de"}})
+	if perr, ok := err.(*ParamError); !ok || perr.Param != "type" {
+		t.Errorf("Expected type ParamError, got %v", err)
+	}
+}
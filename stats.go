@@ -0,0 +1,197 @@
+package stripe
+
+import (
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// StatsKey identifies the requests counted together in Stats: those made
+// with the same method to the same resource, such as "customers" or
+// "invoices".
+type StatsKey struct {
+	Resource string
+	Method   string
+}
+
+// RequestStats counts the requests made to a resource.
+type RequestStats struct {
+	// The number of requests sent.
+	Requests int64
+
+	// The number of requests that failed, including those rate limited.
+	Errors int64
+
+	// The number of requests rejected by Stripe's rate limiter.
+	RateLimited int64
+
+	// The number of rate limited requests sent again by bulk helpers such as
+	// ChargeClient.Export. Each retry is also counted in Requests.
+	Retries int64
+}
+
+var (
+	_statsMu sync.Mutex
+	_stats   = make(map[StatsKey]*RequestStats)
+)
+
+// Stats returns a snapshot of the number of requests made to each resource,
+// by method, since the process started.
+func Stats() map[StatsKey]RequestStats {
+	_statsMu.Lock()
+	defer _statsMu.Unlock()
+	snapshot := make(map[StatsKey]RequestStats, len(_stats))
+	for k, s := range _stats {
+		snapshot[k] = *s
+	}
+	return snapshot
+}
+
+// statsFor returns the stats of requests with the given method and path,
+// adding them if none have been counted yet. _statsMu must be held.
+func statsFor(method, path string) *RequestStats {
+	resource := strings.TrimPrefix(path, "/")
+	if i := strings.Index(resource, "/"); i >= 0 {
+		resource = resource[:i]
+	}
+	key := StatsKey{resource, method}
+	s, ok := _stats[key]
+	if !ok {
+		s = &RequestStats{}
+		_stats[key] = s
+	}
+	return s
+}
+
+// recordRequest counts a request, given its response status or 0 if no
+// response was received.
+func recordRequest(method, path string, status int) {
+	_statsMu.Lock()
+	defer _statsMu.Unlock()
+	s := statsFor(method, path)
+	s.Requests++
+	if status != 200 {
+		s.Errors++
+	}
+	if status == 429 {
+		s.RateLimited++
+	}
+}
+
+// recordRetry counts a rate limited request that is being sent again.
+func recordRetry(method, path string) {
+	_statsMu.Lock()
+	defer _statsMu.Unlock()
+	statsFor(method, path).Retries++
+}
+
+// RateLimitWindow is how far back RateLimit counts rate limited requests.
+const RateLimitWindow = time.Minute
+
+// RateLimitState describes how Stripe's rate limiter has recently treated
+// this process's requests, so that bulk jobs can pace themselves rather than
+// sending requests until they are throttled.
+//
+// Stripe does not report the number of requests remaining before it starts
+// rejecting them, so the state is based only on the rate limited responses
+// received.
+type RateLimitState struct {
+	// The number of requests rate limited in the last RateLimitWindow.
+	Recent int
+
+	// The time the most recent rate limited response was received, or the
+	// zero time if none has been.
+	Last time.Time
+
+	// How long to wait before sending the next request: the remainder of
+	// the Retry-After period sent with the most recent rate limited
+	// response, or zero if that period has passed.
+	Wait time.Duration
+}
+
+var (
+	_limitedAt  []time.Time
+	_retryUntil time.Time
+)
+
+// RateLimit returns the current RateLimitState.
+func RateLimit() RateLimitState {
+	now := _clock.Now()
+
+	_statsMu.Lock()
+	defer _statsMu.Unlock()
+	_limitedAt = trimLimited(_limitedAt, now)
+	state := RateLimitState{Recent: len(_limitedAt)}
+	if n := len(_limitedAt); n > 0 {
+		state.Last = _limitedAt[n-1]
+	}
+	if _retryUntil.After(now) {
+		state.Wait = _retryUntil.Sub(now)
+	}
+	return state
+}
+
+// recordRateLimit records a rate limited response, given the value of its
+// Retry-After header, in seconds, if any.
+func recordRateLimit(retryAfter string) {
+	now := _clock.Now()
+
+	_statsMu.Lock()
+	defer _statsMu.Unlock()
+	_limitedAt = append(trimLimited(_limitedAt, now), now)
+	if secs, err := strconv.Atoi(strings.TrimSpace(retryAfter)); err == nil && secs > 0 {
+		if until := now.Add(time.Duration(secs) * time.Second); until.After(_retryUntil) {
+			_retryUntil = until
+		}
+	}
+}
+
+// the number of times a bulk helper retries a rate limited request before
+// returning the error
+const rateLimitRetries = 5
+
+// the initial wait before retrying a rate limited request, if Stripe did not
+// say how long to wait; it doubles with each retry
+var rateLimitBackoff = time.Second
+
+// paced calls fn, which sends a request with the given method and path, for
+// helpers that send many requests in bulk. It first waits out the Retry-After
+// period of any recent rate limited response, and retries fn with backoff
+// while it is rate limited, counting each retry in Stats. It stops waiting,
+// returning the last error, if stop is closed.
+func paced(method, path string, stop <-chan struct{}, fn func() error) error {
+	var err error
+	backoff := rateLimitBackoff
+	for attempt := 0; ; attempt++ {
+		wait := RateLimit().Wait
+		if attempt > 0 && wait < backoff {
+			wait = backoff
+			backoff *= 2
+		}
+		if wait > 0 {
+			select {
+			case <-time.After(wait):
+			case <-stop:
+				return err
+			}
+		}
+		if attempt > 0 {
+			recordRetry(method, path)
+		}
+		err = fn()
+		if e, ok := err.(*Error); !ok || e.Code != 429 || attempt == rateLimitRetries {
+			return err
+		}
+	}
+}
+
+// trimLimited drops the times that are outside the RateLimitWindow.
+func trimLimited(times []time.Time, now time.Time) []time.Time {
+	cutoff := now.Add(-RateLimitWindow)
+	i := 0
+	for i < len(times) && !times[i].After(cutoff) {
+		i++
+	}
+	return times[i:]
+}
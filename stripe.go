@@ -1,7 +1,6 @@
 package stripe
 
 import (
-	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
@@ -11,6 +10,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 )
 
 // enable logging to print the request and reponses to stdout
@@ -22,6 +22,15 @@ var _key string
 // the default URL for all Stripe API requests
 var _url string = "https://api.stripe.com"
 
+// the default URL for Stripe file uploads
+var _uploadUrl string = "https://files.stripe.com"
+
+// the default URL for Stripe Connect OAuth
+var _connectUrl string = "https://connect.stripe.com"
+
+// reject all requests other than GETs when enabled
+var _readOnly bool
+
 const apiVersion = "2014-03-28"
 
 // SetUrl will override the default Stripe API URL. This is primarily used
@@ -30,23 +39,93 @@ func SetUrl(url string) {
 	_url = url
 }
 
+// SetUploadUrl will override the default Stripe file upload URL. This is
+// primarily used for unit testing.
+func SetUploadUrl(url string) {
+	_uploadUrl = url
+}
+
+// SetConnectUrl will override the default Stripe Connect OAuth URL. This is
+// primarily used for unit testing.
+func SetConnectUrl(url string) {
+	_connectUrl = url
+}
+
 // SetKey will set the default Stripe API key used to authenticate all Stripe
 // API requests.
 func SetKey(key string) {
 	_key = key
 }
 
+// SetReadOnly will, when enabled, reject every request that is not a GET with
+// a *ReadOnlyError before it is sent. This is intended for reporting services
+// that should never mutate billing data, whatever the API key allows.
+func SetReadOnly(readOnly bool) {
+	_readOnly = readOnly
+}
+
+// ReadOnlyError is returned for requests that would mutate data while
+// read-only mode is enabled.
+type ReadOnlyError struct {
+	Method string
+	Path   string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("stripe: %s %s rejected in read-only mode", e.Method, e.Path)
+}
+
 // Available APIs
 var (
-	Charges       = new(ChargeClient)
-	Coupons       = new(CouponClient)
-	Customers     = new(CustomerClient)
-	Invoices      = new(InvoiceClient)
-	InvoiceItems  = new(InvoiceItemClient)
-	Plans         = new(PlanClient)
-	Subscriptions = new(SubscriptionClient)
-	Tokens        = new(TokenClient)
-	Cards         = new(CardClient)
+	Accounts                    = new(AccountClient)
+	ApplePayDomains             = new(ApplePayDomainClient)
+	BalanceTransactions         = new(BalanceTransactionClient)
+	BankAccounts                = new(BankAccountClient)
+	BillingPortalConfigurations = new(BillingPortalConfigurationClient)
+	BillingPortalSessions       = new(BillingPortalSessionClient)
+	Charges                     = new(ChargeClient)
+	CheckoutSessions            = new(CheckoutSessionClient)
+	Coupons                     = new(CouponClient)
+	CustomerBalanceTransactions = new(CustomerBalanceTransactionClient)
+	Customers                   = new(CustomerClient)
+	EarlyFraudWarnings          = new(EarlyFraudWarningClient)
+	Events                      = new(EventClient)
+	Files                       = new(FileClient)
+	InvoiceItems                = new(InvoiceItemClient)
+	Invoices                    = new(InvoiceClient)
+	IssuingAuthorizations       = new(IssuingAuthorizationClient)
+	IssuingCardholders          = new(IssuingCardholderClient)
+	IssuingCards                = new(IssuingCardClient)
+	IssuingDisputes             = new(IssuingDisputeClient)
+	IssuingTransactions         = new(IssuingTransactionClient)
+	Mandates                    = new(MandateClient)
+	OAuth                       = new(OAuthClient)
+	PaymentMethods              = new(PaymentMethodClient)
+	Payouts                     = new(PayoutClient)
+	Persons                     = new(PersonClient)
+	Plans                       = new(PlanClient)
+	Prices                      = new(PriceClient)
+	Products                    = new(ProductClient)
+	ReportRuns                  = new(ReportRunClient)
+	ReportTypes                 = new(ReportTypeClient)
+	Reviews                     = new(ReviewClient)
+	SetupIntents                = new(SetupIntentClient)
+	Sources                     = new(SourceClient)
+	SubscriptionItems           = new(SubscriptionItemClient)
+	Subscriptions               = new(SubscriptionClient)
+	TaxIDs                      = new(TaxIDClient)
+	TaxRates                    = new(TaxRateClient)
+	TerminalConnectionTokens    = new(TerminalConnectionTokenClient)
+	TerminalLocations           = new(TerminalLocationClient)
+	TerminalReaders             = new(TerminalReaderClient)
+	TestClocks                  = new(TestClockClient)
+	Tokens                      = new(TokenClient)
+	Topups                      = new(TopupClient)
+	Transfers                   = new(TransferClient)
+	UsageRecords                = new(UsageRecordClient)
+	ValueListItems              = new(ValueListItemClient)
+	ValueLists                  = new(ValueListClient)
+	Cards                       = new(CardClient)
 )
 
 // SetKeyEnv retrieves the Stripe API key using the STRIPE_API_KEY environment
@@ -62,6 +141,15 @@ func SetKeyEnv() (err error) {
 // query submits an http.Request and parses the JSON-encoded http.Response,
 // storing the result in the value pointed to by v.
 func query(method, path string, values url.Values, v interface{}) error {
+	if _readOnly && method != "GET" {
+		return &ReadOnlyError{method, path}
+	}
+
+	// check the metadata limits before making a round trip
+	if err := validateMetadataValues(values); err != nil {
+		return err
+	}
+
 	// parse the stripe URL
 	endpoint, err := url.Parse(_url)
 	if err != nil {
@@ -94,11 +182,28 @@ func query(method, path string, values url.Values, v interface{}) error {
 	if err != nil {
 		return err
 	}
+	return send(req, path, v)
+}
 
-	req.Header.Set("Stripe-Version", apiVersion)
+// send submits an http.Request built by query or FileClient.Create, and
+// parses the JSON-encoded http.Response, storing the result in the value
+// pointed to by v.
+func send(req *http.Request, path string, v interface{}) error {
+	return sendDecodingErrors(req, path, v, decodeError)
+}
 
-	// submit the http request
-	r, err := http.DefaultClient.Do(req)
+// decodeError decodes the body of a failed response as an *Error.
+func decodeError(status int, body []byte) error {
+	error := Error{Code: status}
+	_codec.Unmarshal(body, &error)
+	return &error
+}
+
+// sendDecodingErrors is send, but decodes the body of a failed response with
+// decodeErr, for endpoints (such as OAuth) that report errors in a different
+// form.
+func sendDecodingErrors(req *http.Request, path string, v interface{}, decodeErr func(status int, body []byte) error) error {
+	r, err := do(req, path)
 	if err != nil {
 		return err
 	}
@@ -118,13 +223,36 @@ func query(method, path string, values url.Values, v interface{}) error {
 
 	// is this an error?
 	if r.StatusCode != 200 {
-		error := Error{}
-		json.Unmarshal(body, &error)
-		return &error
+		return decodeErr(r.StatusCode, body)
 	}
 
 	//parse the JSON response into the response object
-	return json.Unmarshal(body, v)
+	return _codec.Unmarshal(body, v)
+}
+
+// do submits an http.Request with the API version, counting it in the
+// request stats and rate limit state and reporting any warnings sent with
+// the response. The caller must close the response body.
+func do(req *http.Request, path string) (*http.Response, error) {
+	method := req.Method
+	req.Header.Set("Stripe-Version", apiVersion)
+
+	// submit the http request
+	r, err := http.DefaultClient.Do(req)
+	if err != nil {
+		recordRequest(method, path, 0)
+		return nil, err
+	}
+	recordRequest(method, path, r.StatusCode)
+	if r.StatusCode == 429 {
+		recordRateLimit(r.Header.Get("Retry-After"))
+	}
+
+	// surface any deprecation or other warnings sent with the response
+	for _, w := range r.Header.Values("Warning") {
+		reportWarning(&APIWarning{method, path, parseWarning(w)})
+	}
+	return r, nil
 }
 
 // Error encapsulates an error returned by the Stripe REST API.
@@ -142,6 +270,18 @@ func (e *Error) Error() string {
 	return e.Detail.Message
 }
 
+// ParamError is returned when a parameter is invalid, before the request is
+// sent to Stripe.
+type ParamError struct {
+	// The name of the invalid parameter, as sent to Stripe.
+	Param   string
+	Message string
+}
+
+func (e *ParamError) Error() string {
+	return fmt.Sprintf("stripe: invalid %s: %s", e.Param, e.Message)
+}
+
 // Response to a Deletion request.
 type DeleteResp struct {
 	// ID of the Object that was deleted
@@ -150,6 +290,201 @@ type DeleteResp struct {
 	Deleted bool `json:"deleted"`
 }
 
+// ListObject holds the details common to every list returned by the Stripe
+// API, describing a single page of results.
+type ListObject struct {
+	Object string `json:"object"`
+	URL    string `json:"url"`
+	Count  int    `json:"total_count"`
+	More   bool   `json:"has_more"`
+}
+
+// HasMore returns true if there are more results after this page.
+func (l *ListObject) HasMore() bool {
+	return l.More
+}
+
+// MaxPageSize is the largest page size Stripe allows when listing.
+const MaxPageSize = 100
+
+// the page size used by helpers that walk every page of a list
+var _pageSize = MaxPageSize
+
+// SetPageSize sets the default page size used by helpers that walk every
+// page of a list, such as BalanceTransactionClient.ListForPayout, when they
+// are given a page size of 0. The default, MaxPageSize, makes the fewest
+// requests for long exports; a smaller size returns the first results
+// sooner. Sizes are clamped between 1 and MaxPageSize.
+func SetPageSize(n int) {
+	if n < 1 {
+		n = 1
+	}
+	_pageSize = pageSize(n)
+}
+
+// pageSize returns the page size to walk a list with, given the size asked
+// for: the default set with SetPageSize if n is 0, or else n clamped between
+// 1 and MaxPageSize.
+func pageSize(n int) int {
+	switch {
+	case n == 0:
+		return _pageSize
+	case n < 1:
+		return 1
+	case n > MaxPageSize:
+		return MaxPageSize
+	}
+	return n
+}
+
+// the handler called when a truncated sub-list page is read
+var _truncated func(*ListObject)
+
+// SetTruncatedListHandler sets a function to be called whenever the page of a
+// sub-list embedded in another object (such as a customer's cards) is read
+// with Page while the list has more results, which usually means results are
+// being silently dropped. By default a warning is printed if logging is
+// enabled.
+func SetTruncatedListHandler(fn func(*ListObject)) {
+	_truncated = fn
+}
+
+// warnTruncated reports a sub-list page that is read while the list has more
+// results.
+func warnTruncated(l *ListObject) {
+	if !l.More {
+		return
+	}
+	if _truncated != nil {
+		_truncated(l)
+	} else if _log {
+		fmt.Println("WARNING: reading a partial page of", l.URL, "which has more results")
+	}
+}
+
+// APIWarning is a warning, such as the deprecation of a parameter, sent by
+// Stripe in the Warning header of a response.
+type APIWarning struct {
+	// The method and path of the request the warning was sent for.
+	Method string
+	Path   string
+
+	Message string
+}
+
+// the handler called for each warning sent with a response
+var _warning func(*APIWarning)
+
+// SetWarningHandler sets a function to be called for each warning sent by
+// Stripe with a response, so that deprecations can be reported to telemetry
+// before they turn into failing requests. Warnings are also printed if
+// logging is enabled, whether or not a handler is set.
+func SetWarningHandler(fn func(*APIWarning)) {
+	_warning = fn
+}
+
+// reportWarning reports a warning sent with a response.
+func reportWarning(w *APIWarning) {
+	if _log {
+		fmt.Println("WARNING:", w.Method, w.Path, w.Message)
+	}
+	if _warning != nil {
+		_warning(w)
+	}
+}
+
+// parseWarning returns the text of a Warning header, which is sent as a code,
+// an agent and a quoted message. Values in any other form are returned as is.
+func parseWarning(v string) string {
+	parts := strings.SplitN(v, " ", 3)
+	if len(parts) != 3 || !strings.HasPrefix(parts[2], `"`) {
+		return v
+	}
+	text := parts[2][1:]
+	if i := strings.Index(text, `"`); i >= 0 {
+		text = text[:i]
+	}
+	return text
+}
+
+// queryList submits a GET request to a list endpoint, decoding the page of
+// results into the slice pointed to by data. It returns an error if the
+// response is not a list object.
+func queryList(path string, values url.Values, data interface{}) (*ListObject, error) {
+	res := struct {
+		ListObject
+		Data interface{} `json:"data"`
+	}{Data: data}
+	if err := query("GET", path, values, &res); err != nil {
+		return &res.ListObject, err
+	}
+	if res.Object != "list" {
+		return &res.ListObject, fmt.Errorf("stripe: expected list object from %s, got %q", path, res.Object)
+	}
+	return &res.ListObject, nil
+}
+
+// DateFilter restricts a list to objects with a timestamp (such as their
+// creation date) within a range. Either bound may be nil.
+type DateFilter struct {
+	// (Optional) Only include objects at or after this time.
+	Gte *UnixTime
+
+	// (Optional) Only include objects at or before this time.
+	Lte *UnixTime
+}
+
+func (f *DateFilter) appendValues(values url.Values, key string) {
+	if f.Gte != nil {
+		values.Add(key+"[gte]", strconv.FormatInt(f.Gte.Unix(), 10))
+	}
+	if f.Lte != nil {
+		values.Add(key+"[lte]", strconv.FormatInt(f.Lte.Unix(), 10))
+	}
+}
+
+// ShardDateRange splits the range from start to end, inclusive and to the
+// second, into n consecutive, non-overlapping filters of roughly equal
+// length, so that the shards can be listed concurrently. Fewer than n filters
+// are returned if the range is shorter than n seconds, and none if end is
+// before start.
+func ShardDateRange(start, end time.Time, n int) []*DateFilter {
+	first, last := start.Unix(), end.Unix()
+	if last < first {
+		return nil
+	}
+	if n < 1 {
+		n = 1
+	}
+	if span := last - first + 1; int64(n) > span {
+		n = int(span)
+	}
+
+	step := (last - first + 1) / int64(n)
+	filters := make([]*DateFilter, n)
+	for i := range filters {
+		gte := first + int64(i)*step
+		lte := gte + step - 1
+		if i == n-1 {
+			lte = last
+		}
+		filters[i] = &DateFilter{Gte: &UnixTime{time.Unix(gte, 0)}, Lte: &UnixTime{time.Unix(lte, 0)}}
+	}
+	return filters
+}
+
+// Int returns a pointer to the given int, for setting optional numeric params
+// where zero is a meaningful value.
+func Int(v int) *int {
+	return &v
+}
+
+// Bool returns a pointer to the given bool, for setting optional boolean
+// params.
+func Bool(v bool) *bool {
+	return &v
+}
+
 func appendMetadata(values url.Values, meta map[string]string) {
 	for k, v := range meta {
 		values.Add(fmt.Sprintf("metadata[%s]", k), v)
@@ -0,0 +1,323 @@
+package stripe
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// mockRequest records a request received by a mock server.
+type mockRequest struct {
+	Method string
+	Path   string
+	Form   url.Values
+}
+
+// mockServer starts a local server that responds to every request with the
+// given JSON body, and points the package at it. The returned func restores
+// the previous Stripe URL and shuts the server down.
+func mockServer(resp string) (*mockRequest, func()) {
+	req := &mockRequest{}
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		*req = *r
+		w.Write([]byte(resp))
+	})
+	return req, done
+}
+
+// mockRouter starts a local server that responds to each "METHOD /v1/path"
+// key in routes with its JSON body, and to any other request with a 404
+// error. It returns every request received, in order.
+func mockRouter(routes map[string]string) (*[]*mockRequest, func()) {
+	var reqs []*mockRequest
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		reqs = append(reqs, r)
+		resp, ok := routes[r.Method+" "+r.Path]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			resp = `{"error": {"type": "invalid_request_error", "message": "No such route"}}`
+		}
+		w.Write([]byte(resp))
+	})
+	return &reqs, done
+}
+
+// mockHandler starts a local server that passes every request to fn, and
+// points the package at it. The returned func restores the previous Stripe
+// URL and shuts the server down.
+func mockHandler(fn func(http.ResponseWriter, *mockRequest)) func() {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// the request body is form-encoded, but sent without a Content-Type
+		body, _ := ioutil.ReadAll(r.Body)
+		form, _ := url.ParseQuery(string(body))
+		for k, v := range r.URL.Query() {
+			form[k] = v
+		}
+		fn(w, &mockRequest{Method: r.Method, Path: r.URL.Path, Form: form})
+	}))
+	prev := _url
+	SetUrl(srv.URL)
+	return func() {
+		SetUrl(prev)
+		srv.Close()
+	}
+}
+
+// assertForm reports an error for each of the given params that was not sent
+// with the request with the expected value.
+func assertForm(t *testing.T, req *mockRequest, params map[string]string) {
+	t.Helper()
+	for k, v := range params {
+		if got := req.Form.Get(k); got != v {
+			t.Errorf("Expected param %s=%s, got %q", k, v, got)
+		}
+	}
+}
+
+// TestQueryList will test that a page of results is decoded along with the
+// list's url and has_more flag, and that a response that is not a list is
+// rejected.
+func TestQueryList(t *testing.T) {
+	_, done := mockServer(`{"object": "list", "url": "/v1/plans", "has_more": true, "data": [{"id": "plan1"}, {"id": "plan2"}]}`)
+	var plans []*Plan
+	list, err := queryList("/plans", nil, &plans)
+	done()
+	if err != nil {
+		t.Errorf("Expected Plan List, got Error %s", err.Error())
+		return
+	}
+	if len(plans) != 2 || plans[1].ID != "plan2" {
+		t.Errorf("Expected Plans plan1 and plan2, got %v", plans)
+	}
+	if !list.More || list.URL != "/v1/plans" {
+		t.Errorf("Expected List with more results at /v1/plans, got %+v", list)
+	}
+
+	_, done = mockServer(`{"object": "plan", "id": "plan1"}`)
+	defer done()
+	if _, err := queryList("/plans", nil, &plans); err == nil {
+		t.Errorf("Expected Error for a response that is not a list")
+	}
+}
+
+// TestReadOnly will test that read-only mode rejects mutations before they
+// are sent, while still allowing GETs.
+func TestReadOnly(t *testing.T) {
+	SetReadOnly(true)
+	defer SetReadOnly(false)
+
+	req, done := mockServer(`{"id": "cus_1"}`)
+	defer done()
+
+	_, err := Customers.Update("cus_1", &CustomerParams{Description: "Jerry"})
+	if err, ok := err.(*ReadOnlyError); !ok || err.Method != "POST" || err.Path != "/customers/cus_1" {
+		t.Errorf("Expected ReadOnlyError for POST /customers/cus_1, got %v", err)
+	}
+	if _, err := Customers.Delete("cus_1"); err == nil {
+		t.Errorf("Expected ReadOnlyError for DELETE, got nil")
+	}
+	if req.Method != "" {
+		t.Errorf("Expected no request to be sent, got %s %s", req.Method, req.Path)
+	}
+
+	if _, err := Customers.Get("cus_1"); err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+	}
+	if req.Method != "GET" {
+		t.Errorf("Expected GET to be sent, got %q", req.Method)
+	}
+}
+
+// TestSetPageSize will test that the configured page size, clamped to the
+// allowed range, is used when walking every page of a list.
+func TestSetPageSize(t *testing.T) {
+	defer SetPageSize(MaxPageSize)
+
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": []}`)
+	defer done()
+
+	for size, expected := range map[int]string{
+		25:  "25",
+		0:   "1",
+		500: "100",
+	} {
+		SetPageSize(size)
+		if _, err := BalanceTransactions.ListForPayout("po_1", 0); err != nil {
+			t.Errorf("Expected Balance Transactions, got Error %s", err.Error())
+		}
+		if got := req.Form.Get("limit"); got != expected {
+			t.Errorf("Expected limit %s for page size %d, got %q", expected, size, got)
+		}
+	}
+}
+
+// TestWalkerPageSize will test that a page size given to a helper that walks
+// every page of a list overrides the one set with SetPageSize.
+func TestWalkerPageSize(t *testing.T) {
+	SetPageSize(25)
+	defer SetPageSize(MaxPageSize)
+
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": []}`)
+	defer done()
+
+	for _, test := range []struct {
+		Walk  func(size int) error
+		Size  int
+		Limit string
+	}{
+		{func(size int) error { _, err := BalanceTransactions.ListForPayout("po_1", size); return err }, 10, "10"},
+		{func(size int) error { _, err := BalanceTransactions.ListForPayout("po_1", size); return err }, 0, "25"},
+		{func(size int) error { return NewEmailIndex(EmailNormalizer{}).Backfill(size) }, 5, "5"},
+		{func(size int) error { _, err := Charges.listCreated(&DateFilter{}, size); return err }, 500, "100"},
+	} {
+		if err := test.Walk(test.Size); err != nil {
+			t.Errorf("Expected list, got Error %s", err.Error())
+			continue
+		}
+		if got := req.Form.Get("limit"); got != test.Limit {
+			t.Errorf("Expected limit %s for page size %d, got %q", test.Limit, test.Size, got)
+		}
+	}
+}
+
+// TestWarningHandler will test that warnings sent with a response are passed
+// to the warning handler.
+func TestWarningHandler(t *testing.T) {
+	var warnings []*APIWarning
+	SetWarningHandler(func(w *APIWarning) {
+		warnings = append(warnings, w)
+	})
+	defer SetWarningHandler(nil)
+
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		w.Header().Add("Warning", `299 - "The card parameter is deprecated"`)
+		w.Header().Add("Warning", "account_balance is deprecated")
+		w.Write([]byte(`{"id": "cus_1"}`))
+	})
+	defer done()
+
+	if _, err := Customers.Get("cus_1"); err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+	}
+	if len(warnings) != 2 {
+		t.Errorf("Expected 2 warnings, got %d", len(warnings))
+		return
+	}
+	if w := warnings[0]; w.Method != "GET" || w.Path != "/customers/cus_1" || w.Message != "The card parameter is deprecated" {
+		t.Errorf("Expected card deprecation warning for GET /customers/cus_1, got %+v", w)
+	}
+	if w := warnings[1]; w.Message != "account_balance is deprecated" {
+		t.Errorf("Expected account_balance deprecation warning, got %q", w.Message)
+	}
+}
+
+// countingCodec is a Codec that counts the responses it decodes.
+type countingCodec struct {
+	jsonCodec
+	decoded int
+}
+
+func (c *countingCodec) Unmarshal(data []byte, v interface{}) error {
+	c.decoded++
+	return c.jsonCodec.Unmarshal(data, v)
+}
+
+// TestSetCodec will test that responses are decoded with the configured
+// Codec.
+func TestSetCodec(t *testing.T) {
+	codec := &countingCodec{}
+	SetCodec(codec)
+	defer SetCodec(nil)
+
+	_, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "cus_1"}, {"id": "cus_2"}]}`)
+	defer done()
+
+	customers, _, err := Customers.List(10, "", "")
+	if err != nil {
+		t.Errorf("Expected Customers, got Error %s", err.Error())
+		return
+	}
+	if len(customers) != 2 {
+		t.Errorf("Expected 2 Customers, got %d", len(customers))
+	}
+	if codec.decoded != 1 {
+		t.Errorf("Expected 1 response decoded with the Codec, got %d", codec.decoded)
+	}
+}
+
+// TestStats will test that requests, errors and rate limited requests are
+// counted by resource and method.
+func TestStats(t *testing.T) {
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		switch r.Path {
+		case "/v1/coupons/limited":
+			w.WriteHeader(http.StatusTooManyRequests)
+			w.Write([]byte(`{"error": {"type": "rate_limit_error"}}`))
+		case "/v1/coupons/missing":
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"error": {"type": "invalid_request_error"}}`))
+		default:
+			w.Write([]byte(`{"id": "SUMMER"}`))
+		}
+	})
+	defer done()
+
+	key := StatsKey{"coupons", "GET"}
+	before := Stats()[key]
+	for _, id := range []string{"SUMMER", "limited", "missing", "SUMMER"} {
+		Coupons.Get(id)
+	}
+	after := Stats()[key]
+	if got := after.Requests - before.Requests; got != 4 {
+		t.Errorf("Expected 4 requests, got %d", got)
+	}
+	if got := after.Errors - before.Errors; got != 2 {
+		t.Errorf("Expected 2 errors, got %d", got)
+	}
+	if got := after.RateLimited - before.RateLimited; got != 1 {
+		t.Errorf("Expected 1 rate limited request, got %d", got)
+	}
+}
+
+// TestRateLimit will test that rate limited responses are counted within the
+// window, and that the wait suggested by Retry-After counts down with the
+// clock.
+func TestRateLimit(t *testing.T) {
+	// start well after any requests rate limited by other tests
+	now := time.Now().Add(time.Hour)
+	SetClock(fixedClock(now))
+	defer SetClock(nil)
+	defer func() { _limitedAt, _retryUntil = nil, time.Time{} }()
+
+	done := mockHandler(func(w http.ResponseWriter, r *mockRequest) {
+		w.Header().Set("Retry-After", "2")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`{"error": {"type": "rate_limit_error"}}`))
+	})
+	defer done()
+
+	if state := RateLimit(); state.Recent != 0 || state.Wait != 0 {
+		t.Errorf("Expected no recent rate limiting, got %+v", state)
+	}
+	Coupons.Get("SUMMER")
+	Coupons.Get("SUMMER")
+	state := RateLimit()
+	if state.Recent != 2 || !state.Last.Equal(now) {
+		t.Errorf("Expected 2 requests rate limited at %s, got %+v", now, state)
+	}
+	if state.Wait != 2*time.Second {
+		t.Errorf("Expected to wait 2s, got %s", state.Wait)
+	}
+
+	SetClock(fixedClock(now.Add(time.Second)))
+	if state := RateLimit(); state.Wait != time.Second {
+		t.Errorf("Expected to wait 1s, got %s", state.Wait)
+	}
+	SetClock(fixedClock(now.Add(RateLimitWindow)))
+	if state := RateLimit(); state.Recent != 0 || state.Wait != 0 {
+		t.Errorf("Expected rate limiting to have expired, got %+v", state)
+	}
+}
@@ -1,7 +1,9 @@
 package stripe
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"net/url"
 	"strconv"
 )
@@ -15,24 +17,93 @@ const (
 	SubscriptionUnpaid   = "unpaid"
 )
 
+// ErrTrialEndInPast is returned when a trial end is set that is not in the
+// future, allowing for the clock skew set with SetClockSkew. Trial ends of
+// customers attached to a TestClock are left for Stripe to check against the
+// clock's frozen time.
+var ErrTrialEndInPast = errors.New("stripe: trial end must be in the future")
+
+// checkTrialEnd returns ErrTrialEndInPast if trialEnd is set and not in the
+// future, unless the customer is attached to a test clock: either the given
+// testClock, or the clock of the existing customer with the given ID, which
+// is only retrieved when the trial end is in the past.
+func checkTrialEnd(trialEnd *UnixTime, customerID, testClock string) error {
+	if trialEnd == nil || inFuture(trialEnd.Time) || testClock != "" {
+		return nil
+	}
+	if customerID != "" {
+		cust, err := Customers.Get(customerID)
+		if err != nil {
+			return err
+		}
+		if cust.TestClock != "" {
+			return nil
+		}
+	}
+	return ErrTrialEndInPast
+}
+
 // Subscriptions represents a recurring charge a customer's card.
 //
 // see https://stripe.com/docs/api#subscription_object
 type Subscription struct {
-	ID                 string    `json:"id"`
-	Customer           string    `json:"customer"`
-	Status             string    `json:"status"`
-	Plan               *Plan     `json:"plan"`
-	Start              UnixTime  `json:"start"`
-	EndedAt            *UnixTime `json:"ended_at,omitempty"`
-	CurrentPeriodStart UnixTime  `json:"current_period_start"`
-	CurrentPeriodEnd   UnixTime  `json:"current_period_end"`
-	TrialStart         *UnixTime `json:"trial_start,omitempty"`
-	TrialEnd           *UnixTime `json:"trial_end,omitempty"`
-	CanceledAt         *UnixTime `json:"canceled_at,omitempty"`
-	CancelAtPeriodEnd  bool      `json:"cancel_at_period_end"`
-	Quantity           int       `json:"quantity"`
-	Discount           *Discount `json:"discount,omitempty"`
+	ID                 string                `json:"id"`
+	Customer           string                `json:"customer"`
+	Status             string                `json:"status"`
+	Plan               *Plan                 `json:"plan"`
+	Items              *SubscriptionItemList `json:"items,omitempty"`
+	Start              UnixTime              `json:"start"`
+	EndedAt            *UnixTime             `json:"ended_at,omitempty"`
+	CurrentPeriodStart UnixTime              `json:"current_period_start"`
+	CurrentPeriodEnd   UnixTime              `json:"current_period_end"`
+	TrialStart         *UnixTime             `json:"trial_start,omitempty"`
+	TrialEnd           *UnixTime             `json:"trial_end,omitempty"`
+	CanceledAt         *UnixTime             `json:"canceled_at,omitempty"`
+	CancelAtPeriodEnd  bool                  `json:"cancel_at_period_end"`
+	Quantity           int                   `json:"quantity"`
+	Discount           *Discount             `json:"discount,omitempty"`
+	TaxPercent         float64               `json:"tax_percent,omitempty"`
+	DefaultTaxRates    []*TaxRate            `json:"default_tax_rates,omitempty"`
+	Metadata           map[string]string     `json:"metadata,omitempty"`
+}
+
+// RenewalAmount returns the amount, in cents of the plan's currency, that the
+// subscription will bill when it renews at the end of the current period.
+// It prices the quantity by the plan's tiers or transform usage, if any, and
+// applies the subscription's discount if it is still in
+// effect at renewal (in the plan's currency, for amount off coupons with
+// CurrencyOptions), and tax: the exclusive default tax rates if any are set,
+// and the tax percent otherwise. Invoice items and proration are not
+// included. Metered plans bill for usage reported during the period, which
+// is not known here, so RenewalAmount returns 0 for them.
+func (s *Subscription) RenewalAmount() int {
+	if s.Plan == nil || s.Plan.UsageType == UsageMetered {
+		return 0
+	}
+	amount := s.Plan.amountFor(s.Quantity)
+
+	if d := s.Discount; d != nil && d.Coupon != nil && (d.End == nil || d.End.After(s.CurrentPeriodEnd.Time)) {
+		switch {
+		case d.Coupon.PercentOff > 0:
+			amount -= int(math.Floor(float64(amount*d.Coupon.PercentOff)/100 + 0.5))
+		case d.Coupon.AmountOff > 0:
+			amount -= d.Coupon.amountOffIn(s.Plan.Currency)
+		}
+		if amount < 0 {
+			amount = 0
+		}
+	}
+
+	percent := s.TaxPercent
+	if len(s.DefaultTaxRates) != 0 {
+		percent = 0
+		for _, rate := range s.DefaultTaxRates {
+			if !rate.Inclusive {
+				percent += rate.Percentage
+			}
+		}
+	}
+	return amount + int(math.Floor(float64(amount)*percent/100+0.5))
 }
 
 // SubscriptionClient encapsulates operations for updating and canceling
@@ -65,8 +136,15 @@ type SubscriptionParams struct {
 	// (Optional) A new card Token to attach to the customer.
 	Token string
 
-	// (Optional) The quantity you'd like to apply to the subscription you're creating.
-	Quantity int
+	// (Optional) The quantity you'd like to apply to the subscription you're
+	// creating. Zero is sent when set, so use nil to leave it unchanged.
+	Quantity *int
+
+	// (Optional) The IDs of the Tax Rates to apply to the subscription's
+	// invoices, replacing any already set.
+	DefaultTaxRates []string
+
+	Metadata map[string]string
 }
 
 func (c SubscriptionClient) path(customerID, subscriptionID string) string {
@@ -79,10 +157,14 @@ func (c SubscriptionClient) path(customerID, subscriptionID string) string {
 
 func (c SubscriptionClient) Create(customerID string, params *SubscriptionParams) (*Subscription, error) {
 	res := &Subscription{}
-	return res, query("POST", c.path(customerID, ""), c.values(params), res)
+	values, err := c.values(customerID, params)
+	if err != nil {
+		return res, err
+	}
+	return res, query("POST", c.path(customerID, ""), values, res)
 }
 
-func (c SubscriptionClient) values(params *SubscriptionParams) url.Values {
+func (c SubscriptionClient) values(customerID string, params *SubscriptionParams) (url.Values, error) {
 	values := make(url.Values)
 	if params.Plan != "" {
 		values.Add("plan", params.Plan)
@@ -94,17 +176,27 @@ func (c SubscriptionClient) values(params *SubscriptionParams) url.Values {
 		values.Add("prorate", "false")
 	}
 	if params.TrialEnd != nil {
+		if err := checkTrialEnd(params.TrialEnd, customerID, ""); err != nil {
+			return nil, err
+		}
 		values.Add("trial_end", strconv.FormatInt(params.TrialEnd.Unix(), 10))
 	}
-	if params.Quantity != 0 {
-		values.Add("quantity", strconv.Itoa(params.Quantity))
+	if params.Quantity != nil {
+		values.Add("quantity", strconv.Itoa(*params.Quantity))
 	}
+	for _, id := range params.DefaultTaxRates {
+		values.Add("default_tax_rates[]", id)
+	}
+	appendMetadata(values, params.Metadata)
 	if params.Token != "" {
 		values.Add("card", params.Token)
 	} else if params.Card != nil {
-		appendCardParams(values, true, params.Card)
+		if err := params.Card.Validate(); err != nil {
+			return nil, err
+		}
+		appendCardParams(values, params.Card)
 	}
-	return values
+	return values, nil
 }
 
 // Subscribes a customer to a new plan.
@@ -112,7 +204,11 @@ func (c SubscriptionClient) values(params *SubscriptionParams) url.Values {
 // see https://stripe.com/docs/api#update_subscription
 func (c SubscriptionClient) Update(customerID, subscriptionID string, params *SubscriptionParams) (*Subscription, error) {
 	res := &Subscription{}
-	return res, query("POST", c.path(customerID, subscriptionID), c.values(params), res)
+	values, err := c.values(customerID, params)
+	if err != nil {
+		return res, err
+	}
+	return res, query("POST", c.path(customerID, subscriptionID), values, res)
 }
 
 func (c SubscriptionClient) Cancel(customerID, subscriptionID string, atPeriodEnd bool) (*Subscription, error) {
@@ -124,16 +220,37 @@ func (c SubscriptionClient) Cancel(customerID, subscriptionID string, atPeriodEn
 	return res, query("DELETE", c.path(customerID, subscriptionID), values, res)
 }
 
+// Metadata keys used by CancelWithReason to record why, by whom and when a
+// subscription was canceled.
+const (
+	MetadataCancellationReason = "cancellation_reason"
+	MetadataCancellationActor  = "cancellation_actor"
+	MetadataCanceledAt         = "cancellation_requested_at"
+)
+
+// CancelWithReason records the reason for canceling and the actor (such as
+// "customer", "support" or "dunning") in the subscription's metadata, then
+// cancels it, so that churn can be analyzed from a consistent source. The
+// subscription is not canceled if the metadata cannot be recorded.
+func (c SubscriptionClient) CancelWithReason(customerID, subscriptionID, reason, actor string, atPeriodEnd bool) (*Subscription, error) {
+	meta := map[string]string{
+		MetadataCancellationReason: reason,
+		MetadataCancellationActor:  actor,
+		MetadataCanceledAt:         strconv.FormatInt(_clock.Now().Unix(), 10),
+	}
+	if sub, err := c.Update(customerID, subscriptionID, &SubscriptionParams{Metadata: meta}); err != nil {
+		return sub, err
+	}
+	return c.Cancel(customerID, subscriptionID, atPeriodEnd)
+}
+
 func (c SubscriptionClient) Get(customerID, subscriptionID string) (*Subscription, error) {
 	res := &Subscription{}
 	return res, query("GET", c.path(customerID, subscriptionID), nil, res)
 }
 
 func (c SubscriptionClient) List(customerID string, limit int, before, after string) ([]*Subscription, bool, error) {
-	res := struct {
-		ListObject
-		Data []*Subscription
-	}{}
-	err := query("GET", c.path(customerID, ""), listParams(limit, before, after), res)
-	return res.Data, res.More, err
+	var data []*Subscription
+	list, err := queryList(c.path(customerID, ""), listParams(limit, before, after), &data)
+	return data, list.More, err
 }
@@ -0,0 +1,152 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Proration Behaviors
+const (
+	ProrationCreateProrations = "create_prorations"
+	ProrationAlwaysInvoice    = "always_invoice"
+	ProrationNone             = "none"
+)
+
+// SubscriptionItem represents one plan or price, and its quantity, on a
+// subscription with multiple plans.
+//
+// see https://stripe.com/docs/api#subscription_items
+type SubscriptionItem struct {
+	ID           string            `json:"id"`
+	Subscription string            `json:"subscription"`
+	Plan         *Plan             `json:"plan,omitempty"`
+	Price        *Price            `json:"price,omitempty"`
+	Quantity     int               `json:"quantity"`
+	TaxRates     []*TaxRate        `json:"tax_rates,omitempty"`
+	Created      UnixTime          `json:"created"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// SubscriptionItemList is a page of the items on a Subscription.
+type SubscriptionItemList struct {
+	ListObject
+	Data []*SubscriptionItem `json:"data"`
+}
+
+// Len returns the number of items in this page of the list.
+func (l *SubscriptionItemList) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the items in this page of the list, reporting a warning if the
+// list has more results.
+func (l *SubscriptionItemList) Page() []*SubscriptionItem {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
+
+// SubscriptionItemParams encapsulates options for creating and updating
+// Subscription Items.
+type SubscriptionItemParams struct {
+	// The ID of the subscription to add the item to. Only used, and required,
+	// when creating.
+	Subscription string
+
+	// The plan or price of the item. One is required when creating.
+	Plan  string
+	Price string
+
+	// (Optional) The quantity of the plan or price.
+	Quantity *int
+
+	// (Optional) How to prorate the change: ProrationCreateProrations (the
+	// default), ProrationAlwaysInvoice or ProrationNone.
+	ProrationBehavior string
+
+	// (Optional) The IDs of the Tax Rates to apply to this item, replacing
+	// any already set.
+	TaxRates []string
+
+	Metadata map[string]string
+}
+
+// SubscriptionItemClient encapsulates operations for creating, updating,
+// deleting and querying the items of a subscription using the Stripe REST
+// API.
+type SubscriptionItemClient struct{}
+
+// Adds a new item to a subscription.
+//
+// see https://stripe.com/docs/api#create_subscription_item
+func (c SubscriptionItemClient) Create(params *SubscriptionItemParams) (*SubscriptionItem, error) {
+	values := c.values(params)
+	values.Add("subscription", params.Subscription)
+	res := &SubscriptionItem{}
+	return res, query("POST", "/subscription_items", values, res)
+}
+
+// Retrieves the Subscription Item with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_subscription_item
+func (SubscriptionItemClient) Get(id string) (*SubscriptionItem, error) {
+	res := &SubscriptionItem{}
+	return res, query("GET", "/subscription_items/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the plan, price or quantity of the Subscription Item with the given
+// ID.
+//
+// see https://stripe.com/docs/api#update_subscription_item
+func (c SubscriptionItemClient) Update(id string, params *SubscriptionItemParams) (*SubscriptionItem, error) {
+	res := &SubscriptionItem{}
+	return res, query("POST", "/subscription_items/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Removes the Subscription Item with the given ID from its subscription,
+// prorating as given by prorationBehavior, which may be empty for the
+// default.
+//
+// see https://stripe.com/docs/api#delete_subscription_item
+func (SubscriptionItemClient) Delete(id, prorationBehavior string) (bool, error) {
+	values := make(url.Values)
+	if prorationBehavior != "" {
+		values.Add("proration_behavior", prorationBehavior)
+	}
+	resp := DeleteResp{}
+	if err := query("DELETE", "/subscription_items/"+url.QueryEscape(id), values, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of the items on the given subscription.
+//
+// see https://stripe.com/docs/api#list_subscription_items
+func (SubscriptionItemClient) List(subscriptionID string, limit int, before, after string) ([]*SubscriptionItem, bool, error) {
+	var data []*SubscriptionItem
+	params := listParams(limit, before, after)
+	params.Add("subscription", subscriptionID)
+	list, err := queryList("/subscription_items", params, &data)
+	return data, list.More, err
+}
+
+func (SubscriptionItemClient) values(params *SubscriptionItemParams) url.Values {
+	values := make(url.Values)
+	if params.Plan != "" {
+		values.Add("plan", params.Plan)
+	}
+	if params.Price != "" {
+		values.Add("price", params.Price)
+	}
+	if params.Quantity != nil {
+		values.Add("quantity", strconv.Itoa(*params.Quantity))
+	}
+	if params.ProrationBehavior != "" {
+		values.Add("proration_behavior", params.ProrationBehavior)
+	}
+	for _, id := range params.TaxRates {
+		values.Add("tax_rates[]", id)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
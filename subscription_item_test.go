@@ -0,0 +1,61 @@
+package stripe
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestCreateSubscriptionItem will test that an item is added to a
+// subscription with its quantity and proration behavior.
+func TestCreateSubscriptionItem(t *testing.T) {
+	req, done := mockServer(`{"id": "si_1", "subscription": "sub_1", "plan": {"id": "seats", "amount": 1000}, "quantity": 3}`)
+	defer done()
+
+	item, err := SubscriptionItems.Create(&SubscriptionItemParams{
+		Subscription:      "sub_1",
+		Plan:              "seats",
+		Quantity:          Int(3),
+		ProrationBehavior: ProrationNone,
+	})
+	if err != nil {
+		t.Errorf("Expected Subscription Item, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/subscription_items" {
+		t.Errorf("Expected POST /v1/subscription_items, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"subscription":       "sub_1",
+		"plan":               "seats",
+		"quantity":           "3",
+		"proration_behavior": "none",
+	})
+	if item.Plan == nil || item.Plan.ID != "seats" || item.Quantity != 3 {
+		t.Errorf("Expected 3 of Plan seats, got %v", item)
+	}
+}
+
+// TestDeleteSubscriptionItem will test that the proration behavior is sent
+// when removing an item.
+func TestDeleteSubscriptionItem(t *testing.T) {
+	req, done := mockServer(`{"id": "si_1", "deleted": true}`)
+	defer done()
+
+	ok, err := SubscriptionItems.Delete("si_1", ProrationAlwaysInvoice)
+	if err != nil || !ok {
+		t.Errorf("Expected Subscription Item to be deleted, got %v, %v", ok, err)
+	}
+	if req.Method != "DELETE" || req.Form.Get("proration_behavior") != "always_invoice" {
+		t.Errorf("Expected DELETE with proration_behavior always_invoice, got %s %v", req.Method, req.Form)
+	}
+}
+
+// TestSubscriptionItems will test that the items of a multi-plan subscription
+// are decoded.
+func TestSubscriptionItems(t *testing.T) {
+	sub := Subscription{}
+	json.Unmarshal([]byte(`{"id": "sub_1", "items": {"object": "list", "data": [{"id": "si_1", "quantity": 1}, {"id": "si_2", "quantity": 4}]}}`), &sub)
+	if sub.Items == nil || sub.Items.Len() != 2 || sub.Items.Page()[1].Quantity != 4 {
+		t.Errorf("Expected 2 Subscription Items, got %v", sub.Items)
+	}
+}
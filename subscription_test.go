@@ -26,7 +26,7 @@ var (
 		Plan:     "plan1",
 		Coupon:   "test coupon 1",
 		TrialEnd: &UnixTime{time.Now().Add(24 * time.Hour)},
-		Quantity: 5,
+		Quantity: Int(5),
 		Card: &CardParams{
 			Name:     "George Costanza",
 			Number:   "4242424242424242",
@@ -62,7 +62,7 @@ func TestCreateSubscriptionCard(t *testing.T) {
 	// Create the customer, and defer its deletion
 	cust, _ := Customers.Create(&cust1)
 	defer Customers.Delete(cust.ID)
-	if cust.DefaultCard != "" {
+	if cust.DefaultSourceID() != "" {
 		t.Errorf("Expected Customer to be created with a nil card")
 		return
 	}
@@ -80,13 +80,13 @@ func TestCreateSubscriptionCard(t *testing.T) {
 	if err != nil {
 		t.Errorf("Expected Subscription, got error %s", err.Error())
 	}
-	if resp.Quantity != sub2.Quantity {
-		t.Errorf("Expected Quantity %d, got %d", sub2.Quantity, resp.Quantity)
+	if resp.Quantity != *sub2.Quantity {
+		t.Errorf("Expected Quantity %d, got %d", *sub2.Quantity, resp.Quantity)
 	}
 
 	// Check to see if the customer's card was added
 	cust, _ = Customers.Get(cust.ID)
-	if cust.DefaultCard == "" {
+	if cust.DefaultSourceID() == "" {
 		t.Errorf("Expected Subscription to assign a new active customer card")
 	}
 }
@@ -95,7 +95,7 @@ func TestCreateSubscriptionToken(t *testing.T) {
 	// Create the customer, and defer its deletion
 	cust, _ := Customers.Create(&cust1)
 	defer Customers.Delete(cust.ID)
-	if cust.DefaultCard != "" {
+	if cust.DefaultSourceID() != "" {
 		t.Errorf("Expected Customer to be created with a nil card")
 		return
 	}
@@ -116,7 +116,7 @@ func TestCreateSubscriptionToken(t *testing.T) {
 
 	// Check to see if the customer's card was added
 	cust, _ = Customers.Get(cust.ID)
-	if cust.DefaultCard == "" {
+	if cust.DefaultSourceID() == "" {
 		t.Errorf("Expected Subscription to assign a new active customer card")
 	}
 }
@@ -176,3 +176,136 @@ func TestCancelSubscriptionAtPeriodEnd(t *testing.T) {
 		t.Errorf("Expected CancelAtPeriodEnd to be %t, got %t", true, subs.CancelAtPeriodEnd)
 	}
 }
+
+// TestSubscriptionQuantityParam will test that a zero quantity is sent when
+// set, and that no quantity is sent when left nil.
+func TestSubscriptionQuantityParam(t *testing.T) {
+	values, _ := Subscriptions.values("cus_1", &SubscriptionParams{Quantity: Int(0)})
+	if got := values.Get("quantity"); got != "0" {
+		t.Errorf("Expected quantity 0, got %q", got)
+	}
+
+	values, _ = Subscriptions.values("cus_1", &SubscriptionParams{Plan: "plan1"})
+	if _, ok := values["quantity"]; ok {
+		t.Errorf("Expected quantity to be omitted, got %q", values.Get("quantity"))
+	}
+}
+
+// TestSubscriptionRenewalAmount will test that the renewal amount accounts
+// for quantity, discounts still in effect at renewal, and tax.
+func TestSubscriptionRenewalAmount(t *testing.T) {
+	periodEnd := time.Now().Add(24 * time.Hour)
+	before := &UnixTime{periodEnd.Add(-time.Hour)}
+	after := &UnixTime{periodEnd.Add(30 * 24 * time.Hour)}
+
+	tests := []struct {
+		Discount   *Discount
+		TaxPercent float64
+		TaxRates   []*TaxRate
+		Expected   int
+	}{
+		{nil, 0, nil, 3000},
+		{&Discount{Coupon: &Coupon{PercentOff: 25}}, 0, nil, 2250},
+		{&Discount{Coupon: &Coupon{AmountOff: 500, Currency: USD}, End: after}, 0, nil, 2500},
+		{&Discount{Coupon: &Coupon{AmountOff: 500, Currency: USD}, End: before}, 0, nil, 3000},
+		{&Discount{Coupon: &Coupon{AmountOff: 5000, Currency: USD}}, 0, nil, 0},
+		{nil, 8.25, nil, 3248},
+		{nil, 8.25, []*TaxRate{{Percentage: 20}, {Percentage: 5, Inclusive: true}}, 3600},
+	}
+	for i, test := range tests {
+		sub := Subscription{
+			Plan:             &Plan{Amount: 1000, Currency: USD},
+			Quantity:         3,
+			CurrentPeriodEnd: UnixTime{periodEnd},
+			Discount:         test.Discount,
+			TaxPercent:       test.TaxPercent,
+			DefaultTaxRates:  test.TaxRates,
+		}
+		if got := sub.RenewalAmount(); got != test.Expected {
+			t.Errorf("Expected renewal amount %d for case %d, got %d", test.Expected, i, got)
+		}
+	}
+}
+
+// TestSubscriptionRenewalAmountCurrency will test that an amount off coupon
+// is applied in the plan's currency, using its currency options.
+func TestSubscriptionRenewalAmountCurrency(t *testing.T) {
+	coupon := &Coupon{AmountOff: 500, Currency: USD, CurrencyOptions: map[string]*CouponCurrencyOption{EUR: {AmountOff: 450}}}
+	for currency, expected := range map[string]int{USD: 2500, EUR: 2550, GBP: 3000} {
+		sub := Subscription{
+			Plan:     &Plan{Amount: 1000, Currency: currency},
+			Quantity: 3,
+			Discount: &Discount{Coupon: coupon},
+		}
+		if got := sub.RenewalAmount(); got != expected {
+			t.Errorf("Expected renewal amount %d in %s, got %d", expected, currency, got)
+		}
+	}
+}
+
+// TestSubscriptionRenewalAmountPlans will test that the renewal amount of
+// tiered plans is priced by their tiers, and that of metered plans is 0.
+func TestSubscriptionRenewalAmountPlans(t *testing.T) {
+	five, ten := 5, 10
+	tiers := []*Tier{
+		{UpTo: &five, UnitAmount: 1000},
+		{UpTo: &ten, UnitAmount: 800, FlatAmount: 500},
+		{UnitAmount: 500},
+	}
+	tests := []struct {
+		Plan     *Plan
+		Quantity int
+		Expected int
+	}{
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersGraduated, Tiers: tiers}, 3, 3000},
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersGraduated, Tiers: tiers}, 7, 7100},
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersGraduated, Tiers: tiers}, 12, 10500},
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersVolume, Tiers: tiers}, 3, 3000},
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersVolume, Tiers: tiers}, 7, 6100},
+		{&Plan{BillingScheme: BillingTiered, TiersMode: TiersVolume, Tiers: tiers}, 12, 6000},
+		{&Plan{Amount: 100, TransformUsage: &TransformUsage{DivideBy: 5, Round: RoundUp}}, 12, 300},
+		{&Plan{Amount: 100, TransformUsage: &TransformUsage{DivideBy: 5, Round: RoundDown}}, 12, 200},
+		{&Plan{Amount: 1000, UsageType: UsageMetered}, 3, 0},
+	}
+	for i, test := range tests {
+		sub := Subscription{Plan: test.Plan, Quantity: test.Quantity}
+		if got := sub.RenewalAmount(); got != test.Expected {
+			t.Errorf("Test %d: expected renewal amount %d, got %d", i, test.Expected, got)
+		}
+	}
+}
+
+// TestCancelWithReason will test that the reason and actor are recorded in
+// the subscription's metadata before it is canceled.
+func TestCancelWithReason(t *testing.T) {
+	SetClock(fixedClock(time.Unix(1500000000, 0)))
+	defer SetClock(nil)
+
+	path := "/v1/customers/cus_1/subscriptions/sub_1"
+	reqs, done := mockRouter(map[string]string{
+		"POST " + path:   `{"id": "sub_1", "metadata": {"cancellation_reason": "too_expensive"}}`,
+		"DELETE " + path: `{"id": "sub_1", "status": "active", "cancel_at_period_end": true}`,
+	})
+	defer done()
+
+	sub, err := Subscriptions.CancelWithReason("cus_1", "sub_1", "too_expensive", "customer", true)
+	if err != nil {
+		t.Errorf("Expected Subscription, got Error %s", err.Error())
+		return
+	}
+	if len(*reqs) != 2 || (*reqs)[0].Method != "POST" || (*reqs)[1].Method != "DELETE" {
+		t.Errorf("Expected metadata update before cancellation, got %d requests", len(*reqs))
+		return
+	}
+	assertForm(t, (*reqs)[0], map[string]string{
+		"metadata[cancellation_reason]":       "too_expensive",
+		"metadata[cancellation_actor]":        "customer",
+		"metadata[cancellation_requested_at]": "1500000000",
+	})
+	if got := (*reqs)[1].Form.Get("at_period_end"); got != "true" {
+		t.Errorf("Expected param at_period_end=true, got %q", got)
+	}
+	if !sub.CancelAtPeriodEnd {
+		t.Errorf("Expected Subscription to cancel at period end")
+	}
+}
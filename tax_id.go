@@ -0,0 +1,112 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+)
+
+// Tax ID Types
+const (
+	TaxIDAUABN  = "au_abn"
+	TaxIDBRCNPJ = "br_cnpj"
+	TaxIDCABN   = "ca_bn"
+	TaxIDCHVAT  = "ch_vat"
+	TaxIDEUVAT  = "eu_vat"
+	TaxIDGBVAT  = "gb_vat"
+	TaxIDINGST  = "in_gst"
+	TaxIDJPCN   = "jp_cn"
+	TaxIDMXRFC  = "mx_rfc"
+	TaxIDNOVAT  = "no_vat"
+	TaxIDNZGST  = "nz_gst"
+	TaxIDUSEIN  = "us_ein"
+	TaxIDZAVAT  = "za_vat"
+)
+
+// Tax ID Verification Statuses
+const (
+	TaxIDPending     = "pending"
+	TaxIDVerified    = "verified"
+	TaxIDUnverified  = "unverified"
+	TaxIDUnavailable = "unavailable"
+)
+
+// TaxID represents a customer's tax identifier, such as a VAT number, which
+// is shown on their invoices.
+//
+// see https://stripe.com/docs/api#tax_id_object
+type TaxID struct {
+	ID           string             `json:"id"`
+	Type         string             `json:"type"`
+	Value        string             `json:"value"`
+	Country      string             `json:"country,omitempty"`
+	Customer     string             `json:"customer"`
+	Verification *TaxIDVerification `json:"verification,omitempty"`
+	Created      UnixTime           `json:"created"`
+	Livemode     bool               `json:"livemode"`
+}
+
+// TaxIDVerification describes the result of verifying a TaxID with the
+// relevant tax authority. Only some types, such as eu_vat, are verified.
+type TaxIDVerification struct {
+	Status          string `json:"status"`
+	VerifiedName    string `json:"verified_name,omitempty"`
+	VerifiedAddress string `json:"verified_address,omitempty"`
+}
+
+// Verified returns true if the TaxID has been verified by the tax authority.
+func (t *TaxID) Verified() bool {
+	return t.Verification != nil && t.Verification.Status == TaxIDVerified
+}
+
+// TaxIDClient encapsulates operations for creating, deleting and querying
+// the tax IDs of a customer using the Stripe REST API.
+type TaxIDClient struct{}
+
+func (c TaxIDClient) path(customerID, taxID string) string {
+	p := fmt.Sprintf("/customers/%s/tax_ids", url.QueryEscape(customerID))
+	if taxID != "" {
+		p += "/" + url.QueryEscape(taxID)
+	}
+	return p
+}
+
+// Adds a Tax ID of the given type, such as TaxIDEUVAT, to the given
+// customer.
+//
+// see https://stripe.com/docs/api#create_tax_id
+func (c TaxIDClient) Create(customerID, taxIDType, value string) (*TaxID, error) {
+	values := url.Values{
+		"type":  {taxIDType},
+		"value": {value},
+	}
+	res := &TaxID{}
+	return res, query("POST", c.path(customerID, ""), values, res)
+}
+
+// Retrieves the Tax ID with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_tax_id
+func (c TaxIDClient) Get(customerID, taxID string) (*TaxID, error) {
+	res := &TaxID{}
+	return res, query("GET", c.path(customerID, taxID), nil, res)
+}
+
+// Deletes the Tax ID with the given ID from the given customer.
+//
+// see https://stripe.com/docs/api#delete_tax_id
+func (c TaxIDClient) Delete(customerID, taxID string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", c.path(customerID, taxID), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of the given customer's Tax IDs.
+//
+// see https://stripe.com/docs/api#list_tax_ids
+func (c TaxIDClient) List(customerID string, limit int, before, after string) ([]*TaxID, bool, error) {
+	var data []*TaxID
+	list, err := queryList(c.path(customerID, ""), listParams(limit, before, after), &data)
+	return data, list.More, err
+}
@@ -0,0 +1,41 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateTaxID will test that a Tax ID is added to a customer, and
+// decoded with its verification status.
+func TestCreateTaxID(t *testing.T) {
+	req, done := mockServer(`{"id": "txi_1", "type": "eu_vat", "value": "DE123456789", "country": "DE", "customer": "cus_1", "verification": {"status": "verified", "verified_name": "Acme GmbH"}}`)
+	defer done()
+
+	taxID, err := TaxIDs.Create("cus_1", TaxIDEUVAT, "DE123456789")
+	if err != nil {
+		t.Errorf("Expected Tax ID, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/customers/cus_1/tax_ids" {
+		t.Errorf("Expected POST /v1/customers/cus_1/tax_ids, got %s %s", req.Method, req.Path)
+	}
+	if req.Form.Get("type") != "eu_vat" || req.Form.Get("value") != "DE123456789" {
+		t.Errorf("Expected eu_vat DE123456789, got %v", req.Form)
+	}
+	if !taxID.Verified() || taxID.Verification.VerifiedName != "Acme GmbH" {
+		t.Errorf("Expected Tax ID verified as Acme GmbH, got %+v", taxID.Verification)
+	}
+}
+
+// TestDeleteTaxID will test that a Tax ID is deleted from its customer.
+func TestDeleteTaxID(t *testing.T) {
+	req, done := mockServer(`{"id": "txi_1", "deleted": true}`)
+	defer done()
+
+	deleted, err := TaxIDs.Delete("cus_1", "txi_1")
+	if err != nil || !deleted {
+		t.Errorf("Expected Tax ID deleted, got %v, %v", deleted, err)
+	}
+	if req.Method != "DELETE" || req.Path != "/v1/customers/cus_1/tax_ids/txi_1" {
+		t.Errorf("Expected DELETE /v1/customers/cus_1/tax_ids/txi_1, got %s %s", req.Method, req.Path)
+	}
+}
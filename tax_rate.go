@@ -0,0 +1,152 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// TaxRate represents a tax, such as VAT or GST, that can be applied to
+// subscriptions and invoice items.
+//
+// see https://stripe.com/docs/api#tax_rate_object
+type TaxRate struct {
+	ID           string            `json:"id"`
+	DisplayName  string            `json:"display_name"`
+	Description  string            `json:"description,omitempty"`
+	Jurisdiction string            `json:"jurisdiction,omitempty"`
+	Country      string            `json:"country,omitempty"`
+	State        string            `json:"state,omitempty"`
+	Percentage   float64           `json:"percentage"`
+	Inclusive    bool              `json:"inclusive"`
+	Active       bool              `json:"active"`
+	Created      UnixTime          `json:"created"`
+	Livemode     bool              `json:"livemode"`
+	Metadata     map[string]string `json:"metadata,omitempty"`
+}
+
+// TaxRateParams encapsulates options for creating and updating Tax Rates.
+type TaxRateParams struct {
+	// The display name of the tax rate, shown to customers. Required when
+	// creating.
+	DisplayName string
+
+	// The tax rate percentage out of 100. Required when creating, and cannot
+	// be changed afterwards.
+	Percentage float64
+
+	// Whether the tax is included in the amount. Only used when creating.
+	Inclusive bool
+
+	// (Optional) Whether the tax rate can be applied to new subscriptions and
+	// invoice items.
+	Active *bool
+
+	// (Optional) An arbitrary string describing the tax rate, for your own use.
+	Description string
+
+	// (Optional) The jurisdiction of the tax rate, shown on invoices.
+	Jurisdiction string
+
+	// (Optional) The two-letter country code and, within it, the state or
+	// province the tax rate applies to.
+	Country string
+	State   string
+
+	Metadata map[string]string
+}
+
+// TaxRateListParams encapsulates options for listing Tax Rates.
+type TaxRateListParams struct {
+	// (Optional) Only return tax rates that are active or inactive.
+	Active *bool
+
+	// (Optional) Only return tax rates that are inclusive or exclusive.
+	Inclusive *bool
+
+	// (Optional) Only return tax rates created within the given range.
+	Created *DateFilter
+
+	// (Optional) The page size and cursors, as for other lists.
+	Limit  int
+	Before string
+	After  string
+}
+
+// TaxRateClient encapsulates operations for creating, updating and querying
+// tax rates using the Stripe REST API.
+type TaxRateClient struct{}
+
+// Creates a new Tax Rate.
+//
+// see https://stripe.com/docs/api#create_tax_rate
+func (c TaxRateClient) Create(params *TaxRateParams) (*TaxRate, error) {
+	values := c.values(params)
+	values.Add("percentage", strconv.FormatFloat(params.Percentage, 'f', -1, 64))
+	values.Add("inclusive", strconv.FormatBool(params.Inclusive))
+	res := &TaxRate{}
+	return res, query("POST", "/tax_rates", values, res)
+}
+
+// Retrieves the Tax Rate with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_tax_rate
+func (TaxRateClient) Get(id string) (*TaxRate, error) {
+	res := &TaxRate{}
+	return res, query("GET", "/tax_rates/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Tax Rate with the given ID. The percentage and inclusive flag
+// cannot be changed; create a new Tax Rate instead.
+//
+// see https://stripe.com/docs/api#update_tax_rate
+func (c TaxRateClient) Update(id string, params *TaxRateParams) (*TaxRate, error) {
+	res := &TaxRate{}
+	return res, query("POST", "/tax_rates/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Returns a list of your Tax Rates matching the given filters, or the most
+// recent Tax Rates if params is nil.
+//
+// see https://stripe.com/docs/api#list_tax_rates
+func (TaxRateClient) List(params *TaxRateListParams) ([]*TaxRate, bool, error) {
+	if params == nil {
+		params = &TaxRateListParams{}
+	}
+	var data []*TaxRate
+	values := listParams(params.Limit, params.Before, params.After)
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	if params.Inclusive != nil {
+		values.Add("inclusive", strconv.FormatBool(*params.Inclusive))
+	}
+	if params.Created != nil {
+		params.Created.appendValues(values, "created")
+	}
+	list, err := queryList("/tax_rates", values, &data)
+	return data, list.More, err
+}
+
+func (TaxRateClient) values(params *TaxRateParams) url.Values {
+	values := make(url.Values)
+	if params.DisplayName != "" {
+		values.Add("display_name", params.DisplayName)
+	}
+	if params.Active != nil {
+		values.Add("active", strconv.FormatBool(*params.Active))
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.Jurisdiction != "" {
+		values.Add("jurisdiction", params.Jurisdiction)
+	}
+	if params.Country != "" {
+		values.Add("country", params.Country)
+	}
+	if params.State != "" {
+		values.Add("state", params.State)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
@@ -0,0 +1,89 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateTaxRate will test that a Tax Rate's percentage and inclusive
+// flag are sent when creating it.
+func TestCreateTaxRate(t *testing.T) {
+	req, done := mockServer(`{"id": "txr_1", "display_name": "VAT", "percentage": 20.5, "inclusive": true, "active": true}`)
+	defer done()
+
+	rate, err := TaxRates.Create(&TaxRateParams{
+		DisplayName:  "VAT",
+		Percentage:   20.5,
+		Inclusive:    true,
+		Jurisdiction: "DE",
+	})
+	if err != nil {
+		t.Errorf("Expected Tax Rate, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/tax_rates" {
+		t.Errorf("Expected POST /v1/tax_rates, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"display_name": "VAT",
+		"percentage":   "20.5",
+		"inclusive":    "true",
+		"jurisdiction": "DE",
+	})
+	if rate.Percentage != 20.5 || !rate.Inclusive {
+		t.Errorf("Expected inclusive Tax Rate of 20.5, got %v", rate)
+	}
+}
+
+// TestTaxRatesOnSubscriptionsAndInvoiceItems will test that tax rate IDs are
+// sent when updating a subscription and creating an invoice item.
+func TestTaxRatesOnSubscriptionsAndInvoiceItems(t *testing.T) {
+	req, done := mockServer(`{"id": "sub_1", "default_tax_rates": [{"id": "txr_1", "percentage": 19}]}`)
+	defer done()
+
+	sub, err := Subscriptions.Update("cus_1", "sub_1", &SubscriptionParams{
+		DefaultTaxRates: []string{"txr_1", "txr_2"},
+	})
+	if err != nil {
+		t.Errorf("Expected Subscription, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form["default_tax_rates[]"]; len(got) != 2 || got[0] != "txr_1" || got[1] != "txr_2" {
+		t.Errorf("Expected default_tax_rates[] txr_1 and txr_2, got %v", got)
+	}
+	if len(sub.DefaultTaxRates) != 1 || sub.DefaultTaxRates[0].ID != "txr_1" {
+		t.Errorf("Expected Subscription with Tax Rate txr_1, got %v", sub.DefaultTaxRates)
+	}
+
+	_, err = InvoiceItems.Create(&InvoiceItemParams{
+		Customer: "cus_1",
+		Amount:   Int(1000),
+		Currency: "eur",
+		TaxRates: []string{"txr_1"},
+	})
+	if err != nil {
+		t.Errorf("Expected Invoice Item, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form["tax_rates[]"]; len(got) != 1 || got[0] != "txr_1" {
+		t.Errorf("Expected tax_rates[] txr_1, got %v", got)
+	}
+}
+
+// TestListTaxRatesNilParams will test that listing tax rates without params
+// sends no filters.
+func TestListTaxRatesNilParams(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "txr_1"}]}`)
+	defer done()
+
+	rates, _, err := TaxRates.List(nil)
+	if err != nil {
+		t.Errorf("Expected Tax Rates, got Error %s", err.Error())
+		return
+	}
+	if len(req.Form) != 0 {
+		t.Errorf("Expected no params, got %v", req.Form)
+	}
+	if len(rates) != 1 {
+		t.Errorf("Expected 1 TaxRate, got %d", len(rates))
+	}
+}
@@ -0,0 +1,266 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Terminal Reader Statuses
+const (
+	ReaderOnline  = "online"
+	ReaderOffline = "offline"
+)
+
+// Terminal Reader Action Statuses
+const (
+	ReaderActionInProgress = "in_progress"
+	ReaderActionSucceeded  = "succeeded"
+	ReaderActionFailed     = "failed"
+)
+
+// TerminalConnectionToken is a short-lived token that a Terminal SDK uses to
+// connect to readers. Connection tokens are created by the backend and
+// passed to the SDK, rather than being retrieved.
+//
+// see https://stripe.com/docs/api#terminal_connection_token_object
+type TerminalConnectionToken struct {
+	Secret   string `json:"secret"`
+	Location string `json:"location,omitempty"`
+}
+
+// TerminalConnectionTokenClient encapsulates operations for creating
+// Terminal connection tokens using the Stripe REST API.
+type TerminalConnectionTokenClient struct{}
+
+// Creates a new Connection Token, optionally restricting the SDK to the
+// readers at the Location with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_connection_token_create
+func (TerminalConnectionTokenClient) Create(locationID string) (*TerminalConnectionToken, error) {
+	values := make(url.Values)
+	if locationID != "" {
+		values.Add("location", locationID)
+	}
+	res := &TerminalConnectionToken{}
+	return res, query("POST", "/terminal/connection_tokens", values, res)
+}
+
+// TerminalLocation represents a physical place, such as a store, where
+// Terminal readers are used.
+//
+// see https://stripe.com/docs/api#terminal_location_object
+type TerminalLocation struct {
+	ID          string            `json:"id"`
+	DisplayName string            `json:"display_name"`
+	Address     *Address          `json:"address,omitempty"`
+	Livemode    bool              `json:"livemode"`
+	Metadata    map[string]string `json:"metadata,omitempty"`
+}
+
+// TerminalLocationParams encapsulates options for creating and updating
+// Terminal Locations.
+type TerminalLocationParams struct {
+	// The name of the location shown to customers. Required when creating.
+	DisplayName string
+
+	// The address of the location. Required when creating.
+	Address *Address
+
+	Metadata map[string]string
+}
+
+// TerminalLocationClient encapsulates operations for creating, updating,
+// deleting and querying Terminal locations using the Stripe REST API.
+type TerminalLocationClient struct{}
+
+// Creates a new Location.
+//
+// see https://stripe.com/docs/api#terminal_location_create
+func (c TerminalLocationClient) Create(params *TerminalLocationParams) (*TerminalLocation, error) {
+	res := &TerminalLocation{}
+	return res, query("POST", "/terminal/locations", c.values(params), res)
+}
+
+// Retrieves the Location with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_location_retrieve
+func (TerminalLocationClient) Get(id string) (*TerminalLocation, error) {
+	res := &TerminalLocation{}
+	return res, query("GET", "/terminal/locations/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the Location with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_location_update
+func (c TerminalLocationClient) Update(id string, params *TerminalLocationParams) (*TerminalLocation, error) {
+	res := &TerminalLocation{}
+	return res, query("POST", "/terminal/locations/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Deletes the Location with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_location_delete
+func (TerminalLocationClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/terminal/locations/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Locations.
+//
+// see https://stripe.com/docs/api#terminal_location_list
+func (TerminalLocationClient) List(limit int, before, after string) ([]*TerminalLocation, bool, error) {
+	var data []*TerminalLocation
+	list, err := queryList("/terminal/locations", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (TerminalLocationClient) values(params *TerminalLocationParams) url.Values {
+	values := make(url.Values)
+	if params.DisplayName != "" {
+		values.Add("display_name", params.DisplayName)
+	}
+	if params.Address != nil {
+		appendAddress(values, "address", params.Address)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// TerminalReader represents a card reader registered for in-person
+// payments.
+//
+// see https://stripe.com/docs/api#terminal_reader_object
+type TerminalReader struct {
+	ID              string                `json:"id"`
+	Label           string                `json:"label"`
+	Location        string                `json:"location,omitempty"`
+	DeviceType      string                `json:"device_type"`
+	DeviceSwVersion string                `json:"device_sw_version,omitempty"`
+	SerialNumber    string                `json:"serial_number"`
+	IPAddress       string                `json:"ip_address,omitempty"`
+	Status          string                `json:"status,omitempty"`
+	Action          *TerminalReaderAction `json:"action,omitempty"`
+	Livemode        bool                  `json:"livemode"`
+	Metadata        map[string]string     `json:"metadata,omitempty"`
+}
+
+// TerminalReaderAction describes the action a reader is performing, or most
+// recently performed, at the request of the backend.
+type TerminalReaderAction struct {
+	Type           string `json:"type"`
+	Status         string `json:"status"`
+	FailureCode    string `json:"failure_code,omitempty"`
+	FailureMessage string `json:"failure_message,omitempty"`
+
+	// Set for the process_payment_intent action.
+	ProcessPaymentIntent *struct {
+		PaymentIntent string `json:"payment_intent"`
+	} `json:"process_payment_intent,omitempty"`
+}
+
+// TerminalReaderParams encapsulates options for registering and updating
+// Terminal Readers.
+type TerminalReaderParams struct {
+	// The code shown on the reader when it is put into registration mode.
+	// Only used when registering, when it is required.
+	RegistrationCode string
+
+	// (Optional) A name for the reader.
+	Label string
+
+	// The ID of the Location the reader is registered to. Only used when
+	// registering, when it is required.
+	Location string
+
+	Metadata map[string]string
+}
+
+// TerminalReaderClient encapsulates operations for registering, updating,
+// deleting and querying Terminal readers, and for directing them to collect
+// payments, using the Stripe REST API.
+type TerminalReaderClient struct{}
+
+// Registers a new Reader to a Location.
+//
+// see https://stripe.com/docs/api#terminal_reader_create
+func (c TerminalReaderClient) Create(params *TerminalReaderParams) (*TerminalReader, error) {
+	values := c.values(params)
+	values.Add("registration_code", params.RegistrationCode)
+	if params.Location != "" {
+		values.Add("location", params.Location)
+	}
+	res := &TerminalReader{}
+	return res, query("POST", "/terminal/readers", values, res)
+}
+
+// Retrieves the Reader with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_reader_retrieve
+func (TerminalReaderClient) Get(id string) (*TerminalReader, error) {
+	res := &TerminalReader{}
+	return res, query("GET", "/terminal/readers/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the label or metadata of the Reader with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_reader_update
+func (c TerminalReaderClient) Update(id string, params *TerminalReaderParams) (*TerminalReader, error) {
+	res := &TerminalReader{}
+	return res, query("POST", "/terminal/readers/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Deletes the Reader with the given ID.
+//
+// see https://stripe.com/docs/api#terminal_reader_delete
+func (TerminalReaderClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/terminal/readers/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Readers, optionally only those at the Location with
+// the given ID.
+//
+// see https://stripe.com/docs/api#terminal_reader_list
+func (TerminalReaderClient) List(locationID string, limit int, before, after string) ([]*TerminalReader, bool, error) {
+	var data []*TerminalReader
+	params := listParams(limit, before, after)
+	if locationID != "" {
+		params.Add("location", locationID)
+	}
+	list, err := queryList("/terminal/readers", params, &data)
+	return data, list.More, err
+}
+
+// ProcessPaymentIntent directs the Reader with the given ID to collect
+// payment for the PaymentIntent with the given ID. The returned reader's
+// Action reports the progress of the payment.
+//
+// see https://stripe.com/docs/api#terminal_reader_process_payment_intent
+func (TerminalReaderClient) ProcessPaymentIntent(id, paymentIntentID string) (*TerminalReader, error) {
+	values := url.Values{"payment_intent": {paymentIntentID}}
+	res := &TerminalReader{}
+	return res, query("POST", "/terminal/readers/"+url.QueryEscape(id)+"/process_payment_intent", values, res)
+}
+
+// CancelAction cancels the action the Reader with the given ID is
+// performing.
+//
+// see https://stripe.com/docs/api#terminal_reader_cancel_action
+func (TerminalReaderClient) CancelAction(id string) (*TerminalReader, error) {
+	res := &TerminalReader{}
+	return res, query("POST", "/terminal/readers/"+url.QueryEscape(id)+"/cancel_action", nil, res)
+}
+
+func (TerminalReaderClient) values(params *TerminalReaderParams) url.Values {
+	values := make(url.Values)
+	if params.Label != "" {
+		values.Add("label", params.Label)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
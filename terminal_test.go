@@ -0,0 +1,55 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateTerminalLocation will test that a Location is created with its
+// address.
+func TestCreateTerminalLocation(t *testing.T) {
+	req, done := mockServer(`{"id": "tml_1", "display_name": "Main St", "address": {"line1": "1 Main St", "country": "US"}}`)
+	defer done()
+
+	loc, err := TerminalLocations.Create(&TerminalLocationParams{
+		DisplayName: "Main St",
+		Address:     &Address{Line1: "1 Main St", Country: "US"},
+	})
+	if err != nil {
+		t.Errorf("Expected Location, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/terminal/locations" {
+		t.Errorf("Expected POST /v1/terminal/locations, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"display_name":     "Main St",
+		"address[line1]":   "1 Main St",
+		"address[country]": "US",
+	})
+	if loc.Address == nil || loc.Address.Line1 != "1 Main St" {
+		t.Errorf("Expected Location at 1 Main St, got %+v", loc.Address)
+	}
+}
+
+// TestProcessPaymentIntent will test that a Reader is directed to collect a
+// PaymentIntent, returning the reader with its action.
+func TestProcessPaymentIntent(t *testing.T) {
+	req, done := mockServer(`{"id": "tmr_1", "status": "online", "action": {"type": "process_payment_intent", "status": "in_progress", "process_payment_intent": {"payment_intent": "pi_1"}}}`)
+	defer done()
+
+	reader, err := TerminalReaders.ProcessPaymentIntent("tmr_1", "pi_1")
+	if err != nil {
+		t.Errorf("Expected Reader, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/terminal/readers/tmr_1/process_payment_intent" {
+		t.Errorf("Expected POST /v1/terminal/readers/tmr_1/process_payment_intent, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("payment_intent"); got != "pi_1" {
+		t.Errorf("Expected param payment_intent=pi_1, got %q", got)
+	}
+	a := reader.Action
+	if a == nil || a.Status != ReaderActionInProgress || a.ProcessPaymentIntent == nil || a.ProcessPaymentIntent.PaymentIntent != "pi_1" {
+		t.Errorf("Expected Reader processing pi_1, got %+v", a)
+	}
+}
@@ -0,0 +1,84 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Test Clock Statuses
+const (
+	TestClockReady           = "ready"
+	TestClockAdvancing       = "advancing"
+	TestClockInternalFailure = "internal_failure"
+)
+
+// TestClock simulates the passing of time for the customers attached to it,
+// and their subscriptions and invoices, in test mode. Test clocks are
+// deleted automatically some time after they are created.
+//
+// see https://stripe.com/docs/api#test_clock_object
+type TestClock struct {
+	ID           string   `json:"id"`
+	Name         string   `json:"name,omitempty"`
+	FrozenTime   UnixTime `json:"frozen_time"`
+	Status       string   `json:"status"`
+	DeletesAfter UnixTime `json:"deletes_after"`
+	Created      UnixTime `json:"created"`
+	Livemode     bool     `json:"livemode"`
+}
+
+// TestClockClient encapsulates operations for creating, advancing, deleting
+// and querying test clocks using the Stripe REST API.
+type TestClockClient struct{}
+
+// Creates a new Test Clock, frozen at the given time, with an optional name.
+//
+// see https://stripe.com/docs/api#create_test_clock
+func (TestClockClient) Create(frozenTime UnixTime, name string) (*TestClock, error) {
+	values := url.Values{"frozen_time": {strconv.FormatInt(frozenTime.Unix(), 10)}}
+	if name != "" {
+		values.Add("name", name)
+	}
+	res := &TestClock{}
+	return res, query("POST", "/test_helpers/test_clocks", values, res)
+}
+
+// Retrieves the Test Clock with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_test_clock
+func (TestClockClient) Get(id string) (*TestClock, error) {
+	res := &TestClock{}
+	return res, query("GET", "/test_helpers/test_clocks/"+url.QueryEscape(id), nil, res)
+}
+
+// Advances the Test Clock with the given ID to the given time, which must be
+// after its current frozen time. The clock is advancing until the
+// subscriptions and invoices of its customers have caught up; poll it with
+// Get until it is ready again.
+//
+// see https://stripe.com/docs/api#advance_test_clock
+func (TestClockClient) Advance(id string, frozenTime UnixTime) (*TestClock, error) {
+	values := url.Values{"frozen_time": {strconv.FormatInt(frozenTime.Unix(), 10)}}
+	res := &TestClock{}
+	return res, query("POST", "/test_helpers/test_clocks/"+url.QueryEscape(id)+"/advance", values, res)
+}
+
+// Deletes the Test Clock with the given ID, along with its customers.
+//
+// see https://stripe.com/docs/api#delete_test_clock
+func (TestClockClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/test_helpers/test_clocks/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Test Clocks.
+//
+// see https://stripe.com/docs/api#list_test_clocks
+func (TestClockClient) List(limit int, before, after string) ([]*TestClock, bool, error) {
+	var data []*TestClock
+	list, err := queryList("/test_helpers/test_clocks", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
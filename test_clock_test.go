@@ -0,0 +1,47 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestAdvanceTestClock will test that a Test Clock is advanced to the given
+// frozen time.
+func TestAdvanceTestClock(t *testing.T) {
+	req, done := mockServer(`{"id": "clock_1", "frozen_time": 1502592000, "status": "advancing"}`)
+	defer done()
+
+	clock, err := TestClocks.Advance("clock_1", UnixTime{time.Unix(1502592000, 0)})
+	if err != nil {
+		t.Errorf("Expected Test Clock, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/test_helpers/test_clocks/clock_1/advance" {
+		t.Errorf("Expected POST /v1/test_helpers/test_clocks/clock_1/advance, got %s %s", req.Method, req.Path)
+	}
+	if got := req.Form.Get("frozen_time"); got != "1502592000" {
+		t.Errorf("Expected param frozen_time=1502592000, got %q", got)
+	}
+	if clock.Status != TestClockAdvancing || clock.FrozenTime.Unix() != 1502592000 {
+		t.Errorf("Expected advancing Test Clock, got %+v", clock)
+	}
+}
+
+// TestCreateCustomerWithTestClock will test that a customer is attached to a
+// Test Clock when created.
+func TestCreateCustomerWithTestClock(t *testing.T) {
+	req, done := mockServer(`{"id": "cus_1", "test_clock": "clock_1"}`)
+	defer done()
+
+	cust, err := Customers.Create(&CustomerParams{Email: "kramer@example.com", TestClock: "clock_1"})
+	if err != nil {
+		t.Errorf("Expected Customer, got Error %s", err.Error())
+		return
+	}
+	if got := req.Form.Get("test_clock"); got != "clock_1" {
+		t.Errorf("Expected param test_clock=clock_1, got %q", got)
+	}
+	if cust.TestClock != "clock_1" {
+		t.Errorf("Expected Customer on clock_1, got %q", cust.TestClock)
+	}
+}
@@ -28,8 +28,11 @@ type TokenClient struct{}
 // see https://stripe.com/docs/api#create_token
 func (TokenClient) Create(params *CardParams) (*Token, error) {
 	token := &Token{}
+	if err := params.Validate(); err != nil {
+		return token, err
+	}
 	values := make(url.Values)
-	appendCardParams(values, true, params)
+	appendCardParams(values, params)
 
 	err := query("POST", "/tokens", values, token)
 	return token, err
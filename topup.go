@@ -0,0 +1,120 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Top-up Statuses
+const (
+	TopupPending   = "pending"
+	TopupSucceeded = "succeeded"
+	TopupFailed    = "failed"
+	TopupCanceled  = "canceled"
+	TopupReversed  = "reversed"
+)
+
+// Topup represents funds moved from a bank account into your Stripe balance,
+// such as to pay out more than has been collected.
+//
+// see https://stripe.com/docs/api#topup_object
+type Topup struct {
+	ID                       string            `json:"id"`
+	Amount                   int               `json:"amount"`
+	Currency                 string            `json:"currency"`
+	Description              string            `json:"description,omitempty"`
+	Status                   string            `json:"status"`
+	Source                   *Source           `json:"source,omitempty"`
+	StatementDescriptor      string            `json:"statement_descriptor,omitempty"`
+	TransferGroup            string            `json:"transfer_group,omitempty"`
+	BalanceTransaction       string            `json:"balance_transaction,omitempty"`
+	ExpectedAvailabilityDate *UnixTime         `json:"expected_availability_date,omitempty"`
+	FailureCode              string            `json:"failure_code,omitempty"`
+	FailureMessage           string            `json:"failure_message,omitempty"`
+	Created                  UnixTime          `json:"created"`
+	Livemode                 bool              `json:"livemode"`
+	Metadata                 map[string]string `json:"metadata,omitempty"`
+}
+
+// TopupParams encapsulates options for creating a Top-up.
+type TopupParams struct {
+	// The amount to add to the balance, in cents, and its currency.
+	Amount   int
+	Currency string
+
+	// (Optional) The ID of the bank account Source to take the funds from.
+	// Defaults to the account's default top-up source.
+	Source string
+
+	// (Optional) An arbitrary string attached to the Top-up.
+	Description string
+
+	// (Optional) The description shown on the bank statement, of up to 15
+	// characters.
+	StatementDescriptor string
+
+	// (Optional) Groups the Top-up with the transfers it funds.
+	TransferGroup string
+
+	Metadata map[string]string
+}
+
+// TopupClient encapsulates operations for creating, canceling and querying
+// top-ups using the Stripe REST API.
+type TopupClient struct{}
+
+// Creates a new Top-up.
+//
+// see https://stripe.com/docs/api#create_topup
+func (TopupClient) Create(params *TopupParams) (*Topup, error) {
+	values := url.Values{
+		"amount":   {strconv.Itoa(params.Amount)},
+		"currency": {params.Currency},
+	}
+	if params.Source != "" {
+		values.Add("source", params.Source)
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	if params.StatementDescriptor != "" {
+		values.Add("statement_descriptor", params.StatementDescriptor)
+	}
+	if params.TransferGroup != "" {
+		values.Add("transfer_group", params.TransferGroup)
+	}
+	appendMetadata(values, params.Metadata)
+	res := &Topup{}
+	return res, query("POST", "/topups", values, res)
+}
+
+// Retrieves the Top-up with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_topup
+func (TopupClient) Get(id string) (*Topup, error) {
+	res := &Topup{}
+	return res, query("GET", "/topups/"+url.QueryEscape(id), nil, res)
+}
+
+// Cancels the Top-up with the given ID. Only pending top-ups can be
+// canceled.
+//
+// see https://stripe.com/docs/api#cancel_topup
+func (TopupClient) Cancel(id string) (*Topup, error) {
+	res := &Topup{}
+	return res, query("POST", "/topups/"+url.QueryEscape(id)+"/cancel", nil, res)
+}
+
+// Returns a list of your Top-ups, optionally only those with the given
+// status, such as TopupPending.
+//
+// see https://stripe.com/docs/api#list_topups
+func (TopupClient) List(status string, limit int, before, after string) ([]*Topup, bool, error) {
+	var data []*Topup
+	params := listParams(limit, before, after)
+	if status != "" {
+		params.Add("status", status)
+	}
+	list, err := queryList("/topups", params, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,54 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateTopup will test that a Top-up is created from a bank account
+// source.
+func TestCreateTopup(t *testing.T) {
+	req, done := mockServer(`{"id": "tu_1", "amount": 200000, "currency": "usd", "status": "pending", "source": {"id": "src_1", "type": "ach_debit"}}`)
+	defer done()
+
+	topup, err := Topups.Create(&TopupParams{
+		Amount:              200000,
+		Currency:            "usd",
+		Source:              "src_1",
+		StatementDescriptor: "PAYOUT FUNDS",
+	})
+	if err != nil {
+		t.Errorf("Expected Top-up, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/topups" {
+		t.Errorf("Expected POST /v1/topups, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"amount":               "200000",
+		"currency":             "usd",
+		"source":               "src_1",
+		"statement_descriptor": "PAYOUT FUNDS",
+	})
+	if topup.Status != TopupPending || topup.Source == nil || topup.Source.ID != "src_1" {
+		t.Errorf("Expected pending Top-up from src_1, got %+v", topup)
+	}
+}
+
+// TestCancelTopup will test that canceling a Top-up posts to its cancel
+// endpoint.
+func TestCancelTopup(t *testing.T) {
+	req, done := mockServer(`{"id": "tu_1", "status": "canceled"}`)
+	defer done()
+
+	topup, err := Topups.Cancel("tu_1")
+	if err != nil {
+		t.Errorf("Expected Top-up, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/topups/tu_1/cancel" {
+		t.Errorf("Expected POST /v1/topups/tu_1/cancel, got %s %s", req.Method, req.Path)
+	}
+	if topup.Status != TopupCanceled {
+		t.Errorf("Expected canceled Top-up, got %s", topup.Status)
+	}
+}
@@ -0,0 +1,126 @@
+package stripe
+
+import (
+	"net/url"
+	"strconv"
+)
+
+// Transfer represents funds moved from your balance to a connected account.
+//
+// see https://stripe.com/docs/api#transfer_object
+type Transfer struct {
+	ID                 string            `json:"id"`
+	Amount             int               `json:"amount"`
+	AmountReversed     int               `json:"amount_reversed"`
+	Currency           string            `json:"currency"`
+	Description        string            `json:"description,omitempty"`
+	Destination        string            `json:"destination"`
+	DestinationPayment string            `json:"destination_payment,omitempty"`
+	SourceTransaction  string            `json:"source_transaction,omitempty"`
+	TransferGroup      string            `json:"transfer_group,omitempty"`
+	Reversed           bool              `json:"reversed"`
+	BalanceTransaction string            `json:"balance_transaction"`
+	Created            UnixTime          `json:"created"`
+	Livemode           bool              `json:"livemode"`
+	Metadata           map[string]string `json:"metadata,omitempty"`
+}
+
+// TransferParams encapsulates options for creating a Transfer.
+type TransferParams struct {
+	// The amount to transfer, in cents, and its currency.
+	Amount   int
+	Currency string
+
+	// The ID of the connected account to transfer to.
+	Destination string
+
+	// (Optional) The ID of the charge whose funds are transferred. The
+	// transfer is made when the charge's funds become available, rather
+	// than from the current balance.
+	SourceTransaction string
+
+	// (Optional) Groups the transfer with the charges it is paid from, as
+	// when splitting one payment between several connected accounts.
+	TransferGroup string
+
+	// (Optional) An arbitrary string attached to the Transfer.
+	Description string
+
+	Metadata map[string]string
+}
+
+// TransferListParams encapsulates options for listing Transfers.
+type TransferListParams struct {
+	// (Optional) Only return transfers to the connected account with the
+	// given ID.
+	Destination string
+
+	// (Optional) Only return transfers in the given transfer group.
+	TransferGroup string
+
+	// (Optional) Only return transfers created within the given range.
+	Created *DateFilter
+
+	// (Optional) The page size and cursors, as for other lists.
+	Limit  int
+	Before string
+	After  string
+}
+
+// TransferClient encapsulates operations for creating and querying
+// transfers to connected accounts using the Stripe REST API.
+type TransferClient struct{}
+
+// Creates a new Transfer to a connected account.
+//
+// see https://stripe.com/docs/api#create_transfer
+func (TransferClient) Create(params *TransferParams) (*Transfer, error) {
+	values := url.Values{
+		"amount":      {strconv.Itoa(params.Amount)},
+		"currency":    {params.Currency},
+		"destination": {params.Destination},
+	}
+	if params.SourceTransaction != "" {
+		values.Add("source_transaction", params.SourceTransaction)
+	}
+	if params.TransferGroup != "" {
+		values.Add("transfer_group", params.TransferGroup)
+	}
+	if params.Description != "" {
+		values.Add("description", params.Description)
+	}
+	appendMetadata(values, params.Metadata)
+	res := &Transfer{}
+	return res, query("POST", "/transfers", values, res)
+}
+
+// Retrieves the Transfer with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_transfer
+func (TransferClient) Get(id string) (*Transfer, error) {
+	res := &Transfer{}
+	return res, query("GET", "/transfers/"+url.QueryEscape(id), nil, res)
+}
+
+// Returns a list of your Transfers matching the given filters, or the most
+// recent Transfers if params is nil.
+//
+// see https://stripe.com/docs/api#list_transfers
+func (TransferClient) List(params *TransferListParams) ([]*Transfer, bool, error) {
+	if params == nil {
+		params = &TransferListParams{}
+	}
+	var data []*Transfer
+	values := listParams(params.Limit, params.Before, params.After)
+	if params.Destination != "" {
+		values.Add("destination", params.Destination)
+	}
+	if params.TransferGroup != "" {
+		values.Add("transfer_group", params.TransferGroup)
+	}
+	if params.Created != nil {
+		params.Created.appendValues(values, "created")
+	}
+	list, err := queryList("/transfers", values, &data)
+	return data, list.More, err
+}
@@ -0,0 +1,80 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateTransferFromCharge will test that a Transfer is created from a
+// charge's funds within a transfer group.
+func TestCreateTransferFromCharge(t *testing.T) {
+	req, done := mockServer(`{"id": "tr_1", "amount": 700, "destination": "acct_1", "source_transaction": "ch_1", "transfer_group": "ORDER_1"}`)
+	defer done()
+
+	transfer, err := Transfers.Create(&TransferParams{
+		Amount:            700,
+		Currency:          USD,
+		Destination:       "acct_1",
+		SourceTransaction: "ch_1",
+		TransferGroup:     "ORDER_1",
+	})
+	if err != nil {
+		t.Errorf("Expected Transfer, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/transfers" {
+		t.Errorf("Expected POST /v1/transfers, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"amount":             "700",
+		"currency":           "usd",
+		"destination":        "acct_1",
+		"source_transaction": "ch_1",
+		"transfer_group":     "ORDER_1",
+	})
+	if transfer.SourceTransaction != "ch_1" || transfer.TransferGroup != "ORDER_1" {
+		t.Errorf("Expected Transfer from ch_1 in ORDER_1, got %+v", transfer)
+	}
+}
+
+// TestListByTransferGroup will test that charges and transfers are listed by
+// transfer group.
+func TestListByTransferGroup(t *testing.T) {
+	reqs, done := mockRouter(map[string]string{
+		"GET /v1/charges":   `{"object": "list", "data": [{"id": "ch_1", "transfer_group": "ORDER_1"}]}`,
+		"GET /v1/transfers": `{"object": "list", "data": [{"id": "tr_1", "transfer_group": "ORDER_1"}, {"id": "tr_2", "transfer_group": "ORDER_1"}]}`,
+	})
+	defer done()
+
+	charges, _, err := Charges.List(&ChargeListParams{TransferGroup: "ORDER_1"})
+	if err != nil || len(charges) != 1 {
+		t.Errorf("Expected 1 Charge, got %d, %v", len(charges), err)
+	}
+	transfers, _, err := Transfers.List(&TransferListParams{TransferGroup: "ORDER_1"})
+	if err != nil || len(transfers) != 2 {
+		t.Errorf("Expected 2 Transfers, got %d, %v", len(transfers), err)
+	}
+	for _, req := range *reqs {
+		if got := req.Form.Get("transfer_group"); got != "ORDER_1" {
+			t.Errorf("Expected %s with transfer_group=ORDER_1, got %q", req.Path, got)
+		}
+	}
+}
+
+// TestListTransfersNilParams will test that listing transfers without params
+// sends no filters.
+func TestListTransfersNilParams(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "data": [{"id": "tr_1"}]}`)
+	defer done()
+
+	transfers, _, err := Transfers.List(nil)
+	if err != nil {
+		t.Errorf("Expected Transfers, got Error %s", err.Error())
+		return
+	}
+	if len(req.Form) != 0 {
+		t.Errorf("Expected no params, got %v", req.Form)
+	}
+	if len(transfers) != 1 {
+		t.Errorf("Expected 1 Transfer, got %d", len(transfers))
+	}
+}
@@ -0,0 +1,74 @@
+package stripe
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// Usage Record Actions
+const (
+	UsageIncrement = "increment"
+	UsageSet       = "set"
+)
+
+// UsageRecord represents the usage of a metered subscription item at a point
+// in time.
+//
+// see https://stripe.com/docs/api#usage_record_object
+type UsageRecord struct {
+	ID               string   `json:"id"`
+	Quantity         int      `json:"quantity"`
+	SubscriptionItem string   `json:"subscription_item"`
+	Timestamp        UnixTime `json:"timestamp"`
+	Livemode         bool     `json:"livemode"`
+}
+
+// UsageRecordSummary represents the total usage of a metered subscription
+// item over a billing period.
+//
+// see https://stripe.com/docs/api#usage_record_summary_object
+type UsageRecordSummary struct {
+	ID               string `json:"id"`
+	Invoice          string `json:"invoice,omitempty"`
+	Period           Period `json:"period"`
+	SubscriptionItem string `json:"subscription_item"`
+	TotalUsage       int    `json:"total_usage"`
+	Livemode         bool   `json:"livemode"`
+}
+
+// UsageRecordClient encapsulates operations for reporting and querying the
+// usage of metered subscription items using the Stripe REST API.
+type UsageRecordClient struct{}
+
+// Reports the usage of the given metered subscription item at the given time,
+// which may be zero for the current time. The action is either
+// UsageIncrement, to add the quantity to the usage already reported for the
+// time, or UsageSet, to replace it. It may be empty for the default,
+// UsageIncrement.
+//
+// see https://stripe.com/docs/api#usage_record_create
+func (UsageRecordClient) Create(subscriptionItemID string, quantity int, timestamp time.Time, action string) (*UsageRecord, error) {
+	values := url.Values{"quantity": {strconv.Itoa(quantity)}}
+	if !timestamp.IsZero() {
+		values.Add("timestamp", strconv.FormatInt(timestamp.Unix(), 10))
+	}
+	if action != "" {
+		values.Add("action", action)
+	}
+	res := &UsageRecord{}
+	path := fmt.Sprintf("/subscription_items/%s/usage_records", url.QueryEscape(subscriptionItemID))
+	return res, query("POST", path, values, res)
+}
+
+// Returns a list of the usage summaries of the given metered subscription
+// item, one for each billing period.
+//
+// see https://stripe.com/docs/api#usage_record_summary_list
+func (UsageRecordClient) ListSummaries(subscriptionItemID string, limit int, before, after string) ([]*UsageRecordSummary, bool, error) {
+	var data []*UsageRecordSummary
+	path := fmt.Sprintf("/subscription_items/%s/usage_record_summaries", url.QueryEscape(subscriptionItemID))
+	list, err := queryList(path, listParams(limit, before, after), &data)
+	return data, list.More, err
+}
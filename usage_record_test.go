@@ -0,0 +1,56 @@
+package stripe
+
+import (
+	"testing"
+	"time"
+)
+
+// TestCreateUsageRecord will test that usage is reported for a subscription
+// item with its timestamp and action.
+func TestCreateUsageRecord(t *testing.T) {
+	req, done := mockServer(`{"id": "mbur_1", "quantity": 42, "subscription_item": "si_1", "timestamp": 1500000000}`)
+	defer done()
+
+	rec, err := UsageRecords.Create("si_1", 42, time.Unix(1500000000, 0), UsageSet)
+	if err != nil {
+		t.Errorf("Expected Usage Record, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/subscription_items/si_1/usage_records" {
+		t.Errorf("Expected POST /v1/subscription_items/si_1/usage_records, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"quantity":  "42",
+		"timestamp": "1500000000",
+		"action":    "set",
+	})
+	if rec.Quantity != 42 || rec.Timestamp.Unix() != 1500000000 {
+		t.Errorf("Expected 42 used at 1500000000, got %v", rec)
+	}
+
+	if _, err := UsageRecords.Create("si_1", 1, time.Time{}, ""); err != nil {
+		t.Errorf("Expected Usage Record, got Error %s", err.Error())
+	}
+	if _, ok := req.Form["timestamp"]; ok {
+		t.Errorf("Expected timestamp to be omitted")
+	}
+}
+
+// TestListUsageRecordSummaries will test that the usage summaries of a
+// subscription item are listed.
+func TestListUsageRecordSummaries(t *testing.T) {
+	req, done := mockServer(`{"object": "list", "has_more": false, "data": [{"id": "sis_1", "invoice": "in_1", "total_usage": 120, "period": {"start": 1500000000, "end": 1502678400}}]}`)
+	defer done()
+
+	sums, _, err := UsageRecords.ListSummaries("si_1", 10, "", "")
+	if err != nil {
+		t.Errorf("Expected Usage Record Summaries, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/subscription_items/si_1/usage_record_summaries" {
+		t.Errorf("Expected /v1/subscription_items/si_1/usage_record_summaries, got %s", req.Path)
+	}
+	if len(sums) != 1 || sums[0].TotalUsage != 120 || sums[0].Period.End.Unix() != 1502678400 {
+		t.Errorf("Expected summary of 120 used, got %v", sums)
+	}
+}
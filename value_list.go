@@ -0,0 +1,191 @@
+package stripe
+
+import (
+	"net/url"
+)
+
+// Value List Item Types
+const (
+	ValueListCardFingerprint = "card_fingerprint"
+	ValueListCardBin         = "card_bin"
+	ValueListEmail           = "email"
+	ValueListIPAddress       = "ip_address"
+	ValueListCountry         = "country"
+	ValueListString          = "string"
+	ValueListCaseSensitive   = "case_sensitive_string"
+)
+
+// ValueList represents a Radar list of values, such as emails, card
+// fingerprints or IP addresses, that rules can allow or block.
+//
+// see https://stripe.com/docs/api#radar_value_list_object
+type ValueList struct {
+	ID        string             `json:"id"`
+	Alias     string             `json:"alias"`
+	Name      string             `json:"name"`
+	ItemType  string             `json:"item_type"`
+	ListItems *ValueListItemList `json:"list_items,omitempty"`
+	CreatedBy string             `json:"created_by,omitempty"`
+	Created   UnixTime           `json:"created"`
+	Livemode  bool               `json:"livemode"`
+	Metadata  map[string]string  `json:"metadata,omitempty"`
+}
+
+// ValueListItem represents a single value in a Radar ValueList.
+//
+// see https://stripe.com/docs/api#radar_value_list_item_object
+type ValueListItem struct {
+	ID        string   `json:"id"`
+	Value     string   `json:"value"`
+	ValueList string   `json:"value_list"`
+	CreatedBy string   `json:"created_by,omitempty"`
+	Created   UnixTime `json:"created"`
+	Livemode  bool     `json:"livemode"`
+}
+
+// ValueListItemList is a page of the items in a ValueList.
+type ValueListItemList struct {
+	ListObject
+	Data []*ValueListItem `json:"data"`
+}
+
+// Len returns the number of items in this page of the list.
+func (l *ValueListItemList) Len() int {
+	return len(l.Data)
+}
+
+// Page returns the items in this page of the list, reporting a warning if the
+// list has more results.
+func (l *ValueListItemList) Page() []*ValueListItem {
+	warnTruncated(&l.ListObject)
+	return l.Data
+}
+
+// ValueListParams encapsulates options for creating and updating Value
+// Lists.
+type ValueListParams struct {
+	// The name used to refer to the list in Radar rules. Required when
+	// creating.
+	Alias string
+
+	// The human-readable name of the list. Required when creating.
+	Name string
+
+	// (Optional) The type of the items in the list, such as
+	// ValueListEmail. Only used when creating; the default is
+	// ValueListString.
+	ItemType string
+
+	Metadata map[string]string
+}
+
+// ValueListClient encapsulates operations for creating, updating, deleting
+// and querying Radar value lists using the Stripe REST API.
+type ValueListClient struct{}
+
+// Creates a new Value List.
+//
+// see https://stripe.com/docs/api#create_radar_value_list
+func (c ValueListClient) Create(params *ValueListParams) (*ValueList, error) {
+	values := c.values(params)
+	if params.ItemType != "" {
+		values.Add("item_type", params.ItemType)
+	}
+	res := &ValueList{}
+	return res, query("POST", "/radar/value_lists", values, res)
+}
+
+// Retrieves the Value List with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_radar_value_list
+func (ValueListClient) Get(id string) (*ValueList, error) {
+	res := &ValueList{}
+	return res, query("GET", "/radar/value_lists/"+url.QueryEscape(id), nil, res)
+}
+
+// Updates the alias, name or metadata of the Value List with the given ID.
+//
+// see https://stripe.com/docs/api#update_radar_value_list
+func (c ValueListClient) Update(id string, params *ValueListParams) (*ValueList, error) {
+	res := &ValueList{}
+	return res, query("POST", "/radar/value_lists/"+url.QueryEscape(id), c.values(params), res)
+}
+
+// Deletes the Value List with the given ID. Lists referenced by rules cannot
+// be deleted.
+//
+// see https://stripe.com/docs/api#delete_radar_value_list
+func (ValueListClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/radar/value_lists/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of your Value Lists.
+//
+// see https://stripe.com/docs/api#list_radar_value_lists
+func (ValueListClient) List(limit int, before, after string) ([]*ValueList, bool, error) {
+	var data []*ValueList
+	list, err := queryList("/radar/value_lists", listParams(limit, before, after), &data)
+	return data, list.More, err
+}
+
+func (ValueListClient) values(params *ValueListParams) url.Values {
+	values := make(url.Values)
+	if params.Alias != "" {
+		values.Add("alias", params.Alias)
+	}
+	if params.Name != "" {
+		values.Add("name", params.Name)
+	}
+	appendMetadata(values, params.Metadata)
+	return values
+}
+
+// ValueListItemClient encapsulates operations for adding, removing and
+// querying the items of Radar value lists using the Stripe REST API.
+type ValueListItemClient struct{}
+
+// Adds the value to the Value List with the given ID.
+//
+// see https://stripe.com/docs/api#create_radar_value_list_item
+func (ValueListItemClient) Create(valueListID, value string) (*ValueListItem, error) {
+	values := url.Values{
+		"value_list": {valueListID},
+		"value":      {value},
+	}
+	res := &ValueListItem{}
+	return res, query("POST", "/radar/value_list_items", values, res)
+}
+
+// Retrieves the Value List Item with the given ID.
+//
+// see https://stripe.com/docs/api#retrieve_radar_value_list_item
+func (ValueListItemClient) Get(id string) (*ValueListItem, error) {
+	res := &ValueListItem{}
+	return res, query("GET", "/radar/value_list_items/"+url.QueryEscape(id), nil, res)
+}
+
+// Removes the Value List Item with the given ID from its list.
+//
+// see https://stripe.com/docs/api#delete_radar_value_list_item
+func (ValueListItemClient) Delete(id string) (bool, error) {
+	resp := DeleteResp{}
+	if err := query("DELETE", "/radar/value_list_items/"+url.QueryEscape(id), nil, &resp); err != nil {
+		return false, err
+	}
+	return resp.Deleted, nil
+}
+
+// Returns a list of the items in the Value List with the given ID.
+//
+// see https://stripe.com/docs/api#list_radar_value_list_items
+func (ValueListItemClient) List(valueListID string, limit int, before, after string) ([]*ValueListItem, bool, error) {
+	var data []*ValueListItem
+	params := listParams(limit, before, after)
+	params.Add("value_list", valueListID)
+	list, err := queryList("/radar/value_list_items", params, &data)
+	return data, list.More, err
+}
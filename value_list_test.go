@@ -0,0 +1,57 @@
+package stripe
+
+import (
+	"testing"
+)
+
+// TestCreateValueList will test that a Value List is created with its item
+// type.
+func TestCreateValueList(t *testing.T) {
+	req, done := mockServer(`{"id": "rsl_1", "alias": "blocked_emails", "name": "Blocked Emails", "item_type": "email", "list_items": {"object": "list", "data": []}}`)
+	defer done()
+
+	list, err := ValueLists.Create(&ValueListParams{
+		Alias:    "blocked_emails",
+		Name:     "Blocked Emails",
+		ItemType: ValueListEmail,
+	})
+	if err != nil {
+		t.Errorf("Expected Value List, got Error %s", err.Error())
+		return
+	}
+	if req.Method != "POST" || req.Path != "/v1/radar/value_lists" {
+		t.Errorf("Expected POST /v1/radar/value_lists, got %s %s", req.Method, req.Path)
+	}
+	assertForm(t, req, map[string]string{
+		"alias":     "blocked_emails",
+		"name":      "Blocked Emails",
+		"item_type": "email",
+	})
+	if list.ItemType != ValueListEmail || list.ListItems == nil {
+		t.Errorf("Expected email Value List with items, got %+v", list)
+	}
+}
+
+// TestValueListItems will test that values are added to a list, and that the
+// items are listed by list.
+func TestValueListItems(t *testing.T) {
+	req, done := mockServer(`{"id": "rsli_1", "value": "kramer@example.com", "value_list": "rsl_1"}`)
+	defer done()
+
+	item, err := ValueListItems.Create("rsl_1", "kramer@example.com")
+	if err != nil {
+		t.Errorf("Expected Value List Item, got Error %s", err.Error())
+		return
+	}
+	if req.Path != "/v1/radar/value_list_items" || req.Form.Get("value_list") != "rsl_1" || req.Form.Get("value") != "kramer@example.com" {
+		t.Errorf("Expected kramer@example.com added to rsl_1, got %s %v", req.Path, req.Form)
+	}
+	if item.ValueList != "rsl_1" {
+		t.Errorf("Expected Value List Item of rsl_1, got %q", item.ValueList)
+	}
+
+	ValueListItems.List("rsl_1", 10, "", "")
+	if req.Method != "GET" || req.Form.Get("value_list") != "rsl_1" {
+		t.Errorf("Expected items listed by value_list rsl_1, got %s %v", req.Method, req.Form)
+	}
+}